@@ -5,25 +5,26 @@
 package main
 
 import (
-	"bytes"
 	"context"
-	cryptorand "crypto/rand"
-	"crypto/rsa"
-	"crypto/x509"
-	"crypto/x509/pkix"
-	"encoding/pem"
 	"fmt"
+	atomixv1 "github.com/atomix/controller/pkg/apis/atomix/v1"
+	"github.com/atomix/controller/pkg/controller/util/certs"
 	"github.com/atomix/controller/pkg/controller/util/k8s"
 	"github.com/atomix/runtime/pkg/logging"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	"k8s.io/client-go/kubernetes"
-	"math/big"
 	"os"
 	"runtime"
 	"sigs.k8s.io/controller-runtime"
 	"time"
 )
 
+const (
+	certDir   = "/etc/webhook/certs"
+	secretEnv = "CERTS_SECRET"
+	validity  = 365 * 24 * time.Hour
+)
+
 var log = logging.GetLogger()
 
 func printVersion() {
@@ -39,88 +40,9 @@ func main() {
 
 	printVersion()
 
-	var caPEM, serverCertPEM, serverPrivKeyPEM *bytes.Buffer
-	ca := &x509.Certificate{
-		SerialNumber: big.NewInt(2020),
-		Subject: pkix.Name{
-			Organization: []string{"Open Networking Foundation"},
-		},
-		NotBefore:             time.Now(),
-		NotAfter:              time.Now().AddDate(1, 0, 0),
-		IsCA:                  true,
-		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
-		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
-		BasicConstraintsValid: true,
-	}
-
-	caPrivKey, err := rsa.GenerateKey(cryptorand.Reader, 4096)
-	if err != nil {
-		log.Panic(err)
-	}
-
-	caBytes, err := x509.CreateCertificate(cryptorand.Reader, ca, ca, &caPrivKey.PublicKey, caPrivKey)
-	if err != nil {
-		log.Panic(err)
-	}
-
-	// PEM encode CA cert
-	caPEM = new(bytes.Buffer)
-	_ = pem.Encode(caPEM, &pem.Block{
-		Type:  "CERTIFICATE",
-		Bytes: caBytes,
-	})
-
-	dnsNames := []string{
-		service,
-		fmt.Sprintf("%s.%s", service, namespace),
-		fmt.Sprintf("%s.%s.svc", service, namespace),
-	}
-	commonName := fmt.Sprintf("%s.%s.svc", service, namespace)
-
-	cert := &x509.Certificate{
-		DNSNames:     dnsNames,
-		SerialNumber: big.NewInt(1658),
-		Subject: pkix.Name{
-			CommonName:   commonName,
-			Organization: []string{"Open Networking Foundation"},
-		},
-		NotBefore:    time.Now(),
-		NotAfter:     time.Now().AddDate(1, 0, 0),
-		SubjectKeyId: []byte{1, 2, 3, 4, 6},
-		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
-		KeyUsage:     x509.KeyUsageDigitalSignature,
-	}
-
-	serverPrivKey, err := rsa.GenerateKey(cryptorand.Reader, 4096)
-	if err != nil {
-		log.Panic(err)
-	}
-
-	serverCertBytes, err := x509.CreateCertificate(cryptorand.Reader, cert, ca, &serverPrivKey.PublicKey, caPrivKey)
-	if err != nil {
-		log.Panic(err)
-	}
-
-	serverCertPEM = new(bytes.Buffer)
-	_ = pem.Encode(serverCertPEM, &pem.Block{
-		Type:  "CERTIFICATE",
-		Bytes: serverCertBytes,
-	})
-
-	serverPrivKeyPEM = new(bytes.Buffer)
-	_ = pem.Encode(serverPrivKeyPEM, &pem.Block{
-		Type:  "RSA PRIVATE KEY",
-		Bytes: x509.MarshalPKCS1PrivateKey(serverPrivKey),
-	})
-
-	err = WriteFile("/etc/webhook/certs/tls.crt", serverCertPEM)
-	if err != nil {
-		log.Panic(err)
-	}
-
-	err = WriteFile("/etc/webhook/certs/tls.key", serverPrivKeyPEM)
-	if err != nil {
-		log.Panic(err)
+	secret := os.Getenv(secretEnv)
+	if secret == "" {
+		secret = certs.GetSecretName(service)
 	}
 
 	config := controllerruntime.GetConfigOrDie()
@@ -132,32 +54,26 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
 
-	webhook, err := client.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(ctx, service, metav1.GetOptions{})
+	// Load the certificates shared by all replicas, generating them if this is the first replica
+	bundle, err := certs.GetOrCreateSecret(ctx, client, namespace, secret, service, validity)
 	if err != nil {
 		log.Panic(err)
 	}
 
-	for i, wh := range webhook.Webhooks {
-		wh.ClientConfig.CABundle = caPEM.Bytes()
-		webhook.Webhooks[i] = wh
-	}
-
-	if _, err := client.AdmissionregistrationV1().MutatingWebhookConfigurations().Update(ctx, webhook, metav1.UpdateOptions{}); err != nil {
+	if err := certs.PatchCABundle(ctx, client, service, bundle.CA.CertPEM); err != nil {
 		log.Panic(err)
 	}
-}
 
-// WriteFile writes data in the file at the given path
-func WriteFile(filepath string, sCert *bytes.Buffer) error {
-	f, err := os.Create(filepath)
+	crdClient, err := apiextensions.NewForConfig(config)
 	if err != nil {
-		return err
+		log.Panic(err)
+	}
+	if err := certs.PatchConversionWebhooks(ctx, crdClient, atomixv1.ConvertedResources,
+		namespace, service, atomixv1.ConversionWebhookPath, bundle.CA.CertPEM); err != nil {
+		log.Panic(err)
 	}
-	defer f.Close()
 
-	_, err = f.Write(sCert.Bytes())
-	if err != nil {
-		return err
+	if err := certs.WriteKeyPair(certDir, bundle.Serving); err != nil {
+		log.Panic(err)
 	}
-	return nil
 }
@@ -8,29 +8,43 @@ import (
 	"context"
 	"fmt"
 	"github.com/atomix/controller/pkg/apis"
+	atomixv1 "github.com/atomix/controller/pkg/apis/atomix/v1"
 	corev1beta1 "github.com/atomix/controller/pkg/controller/atomix/v1beta1"
+	controllerconfig "github.com/atomix/controller/pkg/controller/config"
+	"github.com/atomix/controller/pkg/controller/util/certs"
 	"github.com/atomix/controller/pkg/controller/util/k8s"
+	k8snamespace "github.com/atomix/controller/pkg/controller/util/namespace"
+	"github.com/atomix/controller/pkg/controller/util/tracing"
 	"github.com/atomix/runtime/pkg/logging"
 	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"os"
 	"runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+	"time"
 )
 
 var log = logging.GetLogger()
 
-func init() {
-	logging.SetLevel(logging.DebugLevel)
-}
+const certLoadTimeout = 5 * time.Minute
+
+const controllerRuntimeLoggerName = "controller-runtime"
 
 func main() {
 	log.Info(fmt.Sprintf("Go Version: %s", runtime.Version()))
 	log.Info(fmt.Sprintf("Go OS/Arch: %s/%s", runtime.GOOS, runtime.GOARCH))
-	logf.SetLogger(logr.New(&ControllerLogSink{log}))
+	logf.SetLogger(logr.New(&ControllerLogSink{logging.GetLogger(controllerRuntimeLoggerName)}))
 
 	cmd := getCommand()
 	if err := cmd.Execute(); err != nil {
@@ -44,60 +58,310 @@ func getCommand() *cobra.Command {
 		Use:  "atomix-controller",
 		Args: cobra.NoArgs,
 		Run: func(cmd *cobra.Command, args []string) {
-			namespace, _ := cmd.Flags().GetString("namespace")
+			leaderElect, _ := cmd.Flags().GetBool("leader-elect")
+			leaderElectionID, _ := cmd.Flags().GetString("leader-election-id")
+			leaseDuration, _ := cmd.Flags().GetDuration("lease-duration")
+			renewDeadline, _ := cmd.Flags().GetDuration("renew-deadline")
+			retryPeriod, _ := cmd.Flags().GetDuration("retry-period")
+			configPath, _ := cmd.Flags().GetString("config")
+			tracingEndpoint, _ := cmd.Flags().GetString("tracing-endpoint")
+			tracingInsecure, _ := cmd.Flags().GetBool("tracing-insecure")
+			tracingSampleRatio, _ := cmd.Flags().GetFloat64("tracing-sample-ratio")
+			certSecret, _ := cmd.Flags().GetString("cert-secret")
+			certRotation, _ := cmd.Flags().GetBool("cert-rotation")
+			certManager, _ := cmd.Flags().GetBool("cert-manager")
+			certValidity, _ := cmd.Flags().GetDuration("cert-validity")
+			certRenewBefore, _ := cmd.Flags().GetDuration("cert-renew-before")
 
-			// Get a config to talk to the apiserver
-			cfg, err := config.GetConfig()
+			// Load the controller configuration, overriding it with any flags that were set
+			controllerConfig, err := controllerconfig.NewWatcher(configPath, getConfigOverrides(cmd))
 			if err != nil {
 				log.Error(err)
 				os.Exit(1)
 			}
+			if err := controllerconfig.ConfigureLogging(controllerConfig.Get().Logging); err != nil {
+				log.Error(err)
+				os.Exit(1)
+			}
+			controllerConfig.Watch(func(config controllerconfig.Config) {
+				if err := controllerconfig.ConfigureLogging(config.Logging); err != nil {
+					log.Error(err)
+				}
+			})
+			certDir := controllerConfig.Get().Webhook.CertDir
+			watchConfig := controllerConfig.Get().Watch
 
-			// Become the leader before proceeding
-			_ = k8s.BecomeLeader(context.TODO())
-
-			r := k8s.NewFileReady()
-			err = r.Set()
+			// Export traces to the configured collector, if any
+			shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+				ServiceName: "atomix-controller",
+				Endpoint:    tracingEndpoint,
+				Insecure:    tracingInsecure,
+				SampleRatio: tracingSampleRatio,
+			})
 			if err != nil {
 				log.Error(err)
 				os.Exit(1)
 			}
 			defer func() {
-				_ = r.Unset()
+				_ = shutdownTracing(context.Background())
 			}()
 
+			// Get a config to talk to the apiserver
+			cfg, err := config.GetConfig()
+			if err != nil {
+				log.Error(err)
+				os.Exit(1)
+			}
+
+			// Watch a single namespace with the default cache, or a dynamic set of namespaces
+			// Only injected pods are cached.
+			var namespace string
+			var newCache cache.NewCacheFunc
+			var namespaceSelector labels.Selector
+			if watchConfig.NamespaceSelector != "" {
+				namespaceSelector, err = labels.Parse(watchConfig.NamespaceSelector)
+				if err != nil {
+					log.Error(err)
+					os.Exit(1)
+				}
+				newCache = k8snamespace.NewCacheBuilder(watchConfig.Namespaces...)
+			} else if len(watchConfig.Namespaces) > 1 {
+				newCache = k8snamespace.NewCacheBuilder(watchConfig.Namespaces...)
+			} else if len(watchConfig.Namespaces) == 1 {
+				namespace = watchConfig.Namespaces[0]
+			}
+			newCache = newCacheWithSelectors(newCache, corev1beta1.GetCacheSelectors())
+
 			// Create a new Cmd to provide shared dependencies and start components
-			mgr, err := manager.New(cfg, manager.Options{Namespace: namespace})
+			// Leader election gates only the controllers; the webhook server is run on all replicas
+			mgr, err := manager.New(cfg, manager.Options{
+				Namespace:                     namespace,
+				NewCache:                      newCache,
+				LeaderElection:                leaderElect,
+				LeaderElectionID:              leaderElectionID,
+				LeaderElectionNamespace:       k8s.GetNamespace(),
+				LeaderElectionResourceLock:    resourcelock.LeasesResourceLock,
+				LeaderElectionReleaseOnCancel: true,
+				LeaseDuration:                 &leaseDuration,
+				RenewDeadline:                 &renewDeadline,
+				RetryPeriod:                   &retryPeriod,
+				MetricsBindAddress:            controllerConfig.Get().Metrics.BindAddress,
+				HealthProbeBindAddress:        controllerConfig.Get().Health.BindAddress,
+				Port:                          controllerConfig.Get().Webhook.Port,
+				CertDir:                       certDir,
+			})
 			if err != nil {
 				log.Error(err)
 				os.Exit(1)
 			}
 
+			// Reload the configuration when it changes
+			if err := mgr.Add(controllerConfig); err != nil {
+				log.Error(err)
+				os.Exit(1)
+			}
+
+			// Add and remove namespaces from the cache as their labels change
+			if namespaceSelector != nil {
+				client, err := kubernetes.NewForConfig(cfg)
+				if err != nil {
+					log.Error(err)
+					os.Exit(1)
+				}
+				namespaceCache := mgr.GetCache().(*k8snamespace.Cache)
+				if err := mgr.Add(k8snamespace.NewSelectorWatcher(client, namespaceSelector, namespaceCache)); err != nil {
+					log.Error(err)
+					os.Exit(1)
+				}
+			}
+
 			// Setup Scheme for all resources
 			if err := apis.AddToScheme(mgr.GetScheme()); err != nil {
 				log.Error(err)
 				os.Exit(1)
 			}
 
+			if certSecret == "" {
+				certSecret = certs.GetSecretName(k8s.GetName())
+			}
+			certOptions := certs.Options{
+				Service:                   k8s.GetName(),
+				Namespace:                 k8s.GetNamespace(),
+				Secret:                    certSecret,
+				WebhookConfiguration:      k8s.GetName(),
+				ConversionWebhookPath:     atomixv1.ConversionWebhookPath,
+				CustomResourceDefinitions: atomixv1.ConvertedResources,
+				CertDir:                   certDir,
+				Validity:                  certValidity,
+				RenewBefore:               certRenewBefore,
+			}
+
+			if certManager {
+				// Load the webhook serving certificate issued by cert-manager and reload it when it's renewed
+				client, err := kubernetes.NewForConfig(cfg)
+				if err != nil {
+					log.Error(err)
+					os.Exit(1)
+				}
+				crdClient, err := apiextensions.NewForConfig(cfg)
+				if err != nil {
+					log.Error(err)
+					os.Exit(1)
+				}
+				certWatcher := certs.NewSecretWatcher(client, crdClient, certOptions)
+				if err := certWatcher.Load(context.Background(), certLoadTimeout); err != nil {
+					log.Error(err)
+					os.Exit(1)
+				}
+				if err := mgr.Add(certWatcher); err != nil {
+					log.Error(err)
+					os.Exit(1)
+				}
+			} else if certRotation {
+				// Renew the webhook serving certificate before it expires
+				client, err := kubernetes.NewForConfig(cfg)
+				if err != nil {
+					log.Error(err)
+					os.Exit(1)
+				}
+				crdClient, err := apiextensions.NewForConfig(cfg)
+				if err != nil {
+					log.Error(err)
+					os.Exit(1)
+				}
+				if err := mgr.Add(certs.NewManager(client, crdClient, certOptions)); err != nil {
+					log.Error(err)
+					os.Exit(1)
+				}
+			}
+
 			// Add all the controllers
-			if err := corev1beta1.AddControllers(mgr); err != nil {
+			if err := corev1beta1.AddControllers(mgr, controllerConfig); err != nil {
+				log.Error(err)
+				os.Exit(1)
+			}
+
+			// Add health and readiness checks
+			if err := mgr.AddHealthzCheck("ping", healthz.Ping); err != nil {
+				log.Error(err)
+				os.Exit(1)
+			}
+			if err := mgr.AddReadyzCheck("cache", k8s.NewCacheSyncChecker(mgr.GetCache())); err != nil {
+				log.Error(err)
+				os.Exit(1)
+			}
+			if err := mgr.AddReadyzCheck("cert", certs.NewChecker(certDir)); err != nil {
+				log.Error(err)
+				os.Exit(1)
+			}
+			if err := mgr.AddReadyzCheck("webhook", mgr.GetWebhookServer().StartedChecker()); err != nil {
 				log.Error(err)
 				os.Exit(1)
 			}
 
 			// Start the manager
 			log.Info("Starting the Manager")
-			mgr.GetWebhookServer().Port = 443
 			if err := mgr.Start(signals.SetupSignalHandler()); err != nil {
 				log.Error(err, "controller exited non-zero")
 				os.Exit(1)
 			}
 		},
 	}
-	cmd.Flags().StringP("namespace", "n", "", "the namespace in which to run the controller")
+	cmd.Flags().StringP("config", "c", controllerconfig.DefaultPath, "the path to the controller configuration file")
+	cmd.Flags().StringSliceP("namespace", "n", nil, "the namespaces to watch; all namespaces are watched if neither namespaces nor a namespace selector are set")
+	cmd.Flags().String("namespace-selector", "", "a label selector for additional namespaces to watch")
+	cmd.Flags().Bool("leader-elect", true, "whether to enable leader election for the controllers")
+	cmd.Flags().String("leader-election-id", "atomix-controller", "the name of the lease used for leader election")
+	cmd.Flags().Duration("lease-duration", 15*time.Second, "the duration non-leader candidates wait before acquiring leadership")
+	cmd.Flags().Duration("renew-deadline", 10*time.Second, "the duration the leader retries renewing leadership before stepping down")
+	cmd.Flags().Duration("retry-period", 2*time.Second, "the duration candidates wait between leader election actions")
+	cmd.Flags().Int("webhook-port", 0, "the port on which the webhook server listens")
+	cmd.Flags().String("metrics-bind-address", "", "the address to which the Prometheus metrics endpoint binds")
+	cmd.Flags().String("health-probe-bind-address", "", "the address to which the health and readiness probe endpoints bind")
+	cmd.Flags().Int("max-concurrent-reconciles", 0, "the maximum number of concurrent reconciles for each controller")
+	cmd.Flags().Duration("rate-limiter-base-delay", 0, "the delay before controllers retry a failed request")
+	cmd.Flags().Duration("rate-limiter-max-delay", 0, "the maximum delay between controller retries of a failed request")
+	cmd.Flags().String("proxy-image", "", "the image of injected proxies")
+	cmd.Flags().String("proxy-image-pull-policy", "", "the image pull policy of injected proxies")
+	cmd.Flags().String("runtime-version", "", "the runtime version of the proxy image")
+	cmd.Flags().String("log-level", "", "the root log level, overriding the level in the logging configuration")
+	cmd.Flags().String("logging-config", "", "the path to the logging configuration file")
+	cmd.Flags().String("tracing-endpoint", "", "the address of the OTLP gRPC collector to which traces are exported; tracing is disabled if empty")
+	cmd.Flags().Bool("tracing-insecure", false, "whether to disable TLS for connections to the tracing collector")
+	cmd.Flags().Float64("tracing-sample-ratio", 1, "the ratio of traces to sample")
+	cmd.Flags().String("cert-dir", "", "the directory from which the webhook server loads its serving certificate")
+	cmd.Flags().String("cert-secret", "", "the name of the Secret in which webhook certificates are shared by all replicas; defaults to '<name>-certs'")
+	cmd.Flags().Bool("cert-rotation", true, "whether to renew the webhook serving certificate before it expires")
+	cmd.Flags().Bool("cert-manager", false, "whether to load the webhook certificate from the cert-secret issued by cert-manager rather than generating it")
+	cmd.Flags().Duration("cert-validity", 365*24*time.Hour, "the duration for which renewed webhook certificates are valid")
+	cmd.Flags().Duration("cert-renew-before", 30*24*time.Hour, "how long before expiration the webhook certificate is renewed")
 	return cmd
 }
 
+// newCacheWithSelectors returns a function creating caches that cache only objects matching the given selectors
+func newCacheWithSelectors(newCache cache.NewCacheFunc, selectors cache.SelectorsByObject) cache.NewCacheFunc {
+	if newCache == nil {
+		newCache = cache.New
+	}
+	return func(config *rest.Config, options cache.Options) (cache.Cache, error) {
+		options.SelectorsByObject = selectors
+		return newCache(config, options)
+	}
+}
+
+// getConfigOverrides returns a function that overrides the controller configuration with the flags that were set
+func getConfigOverrides(cmd *cobra.Command) func(*controllerconfig.Config) {
+	flags := cmd.Flags()
+	return func(config *controllerconfig.Config) {
+		if flags.Changed("namespace") {
+			config.Watch.Namespaces, _ = flags.GetStringSlice("namespace")
+		}
+		if flags.Changed("namespace-selector") {
+			config.Watch.NamespaceSelector, _ = flags.GetString("namespace-selector")
+		}
+		if flags.Changed("webhook-port") {
+			config.Webhook.Port, _ = flags.GetInt("webhook-port")
+		}
+		if flags.Changed("cert-dir") {
+			config.Webhook.CertDir, _ = flags.GetString("cert-dir")
+		}
+		if flags.Changed("metrics-bind-address") {
+			config.Metrics.BindAddress, _ = flags.GetString("metrics-bind-address")
+		}
+		if flags.Changed("health-probe-bind-address") {
+			config.Health.BindAddress, _ = flags.GetString("health-probe-bind-address")
+		}
+		if flags.Changed("max-concurrent-reconciles") {
+			config.Controllers.MaxConcurrentReconciles, _ = flags.GetInt("max-concurrent-reconciles")
+		}
+		if flags.Changed("rate-limiter-base-delay") {
+			config.Controllers.RateLimiter.BaseDelay, _ = flags.GetDuration("rate-limiter-base-delay")
+		}
+		if flags.Changed("rate-limiter-max-delay") {
+			config.Controllers.RateLimiter.MaxDelay, _ = flags.GetDuration("rate-limiter-max-delay")
+		}
+		if flags.Changed("proxy-image") {
+			config.Proxy.Image, _ = flags.GetString("proxy-image")
+		}
+		if flags.Changed("proxy-image-pull-policy") {
+			pullPolicy, _ := flags.GetString("proxy-image-pull-policy")
+			config.Proxy.ImagePullPolicy = corev1.PullPolicy(pullPolicy)
+		}
+		if flags.Changed("runtime-version") {
+			config.Proxy.RuntimeVersion, _ = flags.GetString("runtime-version")
+		}
+		if flags.Changed("log-level") {
+			config.Logging.Level, _ = flags.GetString("log-level")
+		}
+		if flags.Changed("logging-config") {
+			config.Logging.File, _ = flags.GetString("logging-config")
+		}
+	}
+}
+
+// ControllerLogSink is a logr.LogSink that writes controller-runtime logs to an Atomix logger
+// logr verbosity levels are mapped to Atomix log levels: V(0) logs at info level and higher
+// verbosities log at debug level.
 type ControllerLogSink struct {
 	log logging.Logger
 }
@@ -107,15 +371,19 @@ func (l *ControllerLogSink) Init(info logr.RuntimeInfo) {
 }
 
 func (l *ControllerLogSink) Enabled(level int) bool {
-	return true
+	return l.log.Level() <= getLevel(level)
 }
 
 func (l *ControllerLogSink) Info(level int, msg string, keysAndValues ...interface{}) {
-	l.log.WithFields(getFields(keysAndValues...)...).Info(msg)
+	if getLevel(level) == logging.DebugLevel {
+		l.log.Debugw(msg, getFields(keysAndValues...)...)
+	} else {
+		l.log.Infow(msg, getFields(keysAndValues...)...)
+	}
 }
 
 func (l *ControllerLogSink) Error(err error, msg string, keysAndValues ...interface{}) {
-	l.log.WithFields(getFields(keysAndValues...)...).Error(err, msg)
+	l.log.Errorw(msg, append(getFields(keysAndValues...), logging.Error("error", err))...)
 }
 
 func (l *ControllerLogSink) WithValues(keysAndValues ...interface{}) logr.LogSink {
@@ -132,13 +400,60 @@ func (l *ControllerLogSink) WithName(name string) logr.LogSink {
 
 var _ logr.LogSink = (*ControllerLogSink)(nil)
 
+// getLevel maps a logr verbosity level to a log level
+func getLevel(level int) logging.Level {
+	if level > 0 {
+		return logging.DebugLevel
+	}
+	return logging.InfoLevel
+}
+
 func getFields(keysAndValues ...interface{}) []logging.Field {
 	fields := make([]logging.Field, 0, len(keysAndValues)/2)
 	for i := 0; i < len(keysAndValues); i += 2 {
-		key := keysAndValues[i]
-		value := keysAndValues[i+1]
-		field := logging.String(fmt.Sprint(key), fmt.Sprint(value))
-		fields = append(fields, field)
+		key := fmt.Sprint(keysAndValues[i])
+		if i+1 == len(keysAndValues) {
+			fields = append(fields, logging.String(key, "<no value>"))
+			break
+		}
+		fields = append(fields, getField(key, keysAndValues[i+1]))
 	}
 	return fields
 }
+
+func getField(key string, value interface{}) logging.Field {
+	switch v := value.(type) {
+	case string:
+		return logging.String(key, v)
+	case []string:
+		return logging.Strings(key, v)
+	case bool:
+		return logging.Bool(key, v)
+	case int:
+		return logging.Int(key, v)
+	case int32:
+		return logging.Int32(key, v)
+	case int64:
+		return logging.Int64(key, v)
+	case uint:
+		return logging.Uint(key, v)
+	case uint32:
+		return logging.Uint32(key, v)
+	case uint64:
+		return logging.Uint64(key, v)
+	case float32:
+		return logging.Float32(key, v)
+	case float64:
+		return logging.Float64(key, v)
+	case time.Duration:
+		return logging.Duration(key, v)
+	case time.Time:
+		return logging.Time(key, v)
+	case error:
+		return logging.Error(key, v)
+	case fmt.Stringer:
+		return logging.Stringer(key, v)
+	default:
+		return logging.String(key, fmt.Sprintf("%+v", v))
+	}
+}
@@ -75,3 +75,10 @@ atomix-controller image name
 {{- end -}}
 {{- printf "%s:%s" .repository .tag -}}
 {{- end -}}
+
+{{/*
+The port of a controller bind address, e.g. "8080" for ":8080"
+*/}}
+{{- define "atomix-controller.bindPort" -}}
+{{- splitList ":" . | last -}}
+{{- end -}}
@@ -0,0 +1,24 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+// ConversionWebhookPath is the path at which the controller serves the conversion webhook
+const ConversionWebhookPath = "/convert"
+
+// ConvertedResources is the list of resources converted between versions by the conversion webhook
+var ConvertedResources = []string{
+	Resource("profiles").String(),
+	Resource("clusterprofiles").String(),
+	Resource("proxies").String(),
+}
+
+// Hub marks Profile as the conversion hub
+func (*Profile) Hub() {}
+
+// Hub marks ClusterProfile as the conversion hub
+func (*ClusterProfile) Hub() {}
+
+// Hub marks Proxy as the conversion hub
+func (*Proxy) Hub() {}
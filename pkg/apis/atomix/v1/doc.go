@@ -0,0 +1,8 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package v1 contains API Schema definitions for the atomix v1 API group
+// +k8s:deepcopy-gen=package,register
+// +groupName=atomix.io
+package v1
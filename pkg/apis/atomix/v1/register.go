@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package v1 contains API Schema definitions for the atomix v1 API group
+// +k8s:deepcopy-gen=package,register
+// +groupName=atomix.io
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// SchemeGroupVersion is group version used to register these objects
+var SchemeGroupVersion = schema.GroupVersion{Group: "atomix.io", Version: "v1"}
+
+// Kind takes an unqualified kind and returns back a Group qualified GroupKind
+func Kind(kind string) schema.GroupKind {
+	return SchemeGroupVersion.WithKind(kind).GroupKind()
+}
+
+// Resource takes an unqualified resource and returns a Group qualified GroupResource
+func Resource(resource string) schema.GroupResource {
+	return SchemeGroupVersion.WithResource(resource).GroupResource()
+}
+
+var (
+	// SchemeBuilder initializes a scheme builder
+	SchemeBuilder = runtime.NewSchemeBuilder(addKnownTypes)
+	// AddToScheme is a global function that registers this API group & version to a scheme
+	AddToScheme = SchemeBuilder.AddToScheme
+)
+
+// Adds the list of known types to Scheme.
+func addKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(SchemeGroupVersion, &Store{}, &StoreList{})
+	scheme.AddKnownTypes(SchemeGroupVersion, &StoreGrant{}, &StoreGrantList{})
+	scheme.AddKnownTypes(SchemeGroupVersion, &ClusterStore{}, &ClusterStoreList{})
+	scheme.AddKnownTypes(SchemeGroupVersion, &Driver{}, &DriverList{})
+	scheme.AddKnownTypes(SchemeGroupVersion, &Profile{}, &ProfileList{})
+	scheme.AddKnownTypes(SchemeGroupVersion, &ClusterProfile{}, &ClusterProfileList{})
+	scheme.AddKnownTypes(SchemeGroupVersion, &Proxy{}, &ProxyList{})
+	scheme.AddKnownTypes(SchemeGroupVersion, &ProxyPool{}, &ProxyPoolList{})
+	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
+	return nil
+}
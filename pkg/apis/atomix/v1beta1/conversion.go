@@ -0,0 +1,315 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	atomixv1 "github.com/atomix/controller/pkg/apis/atomix/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// storeReferencesAnnotation holds the fields of v1beta1 store references that are not represented in v1
+const storeReferencesAnnotation = "atomix.io/v1beta1-store-references"
+
+// storeReferenceFields are the fields of a v1beta1 store reference that are not represented in v1
+type storeReferenceFields struct {
+	APIVersion      string    `json:"apiVersion,omitempty"`
+	UID             types.UID `json:"uid,omitempty"`
+	ResourceVersion string    `json:"resourceVersion,omitempty"`
+	FieldPath       string    `json:"fieldPath,omitempty"`
+}
+
+// ConvertTo converts the Profile to the v1 hub version
+func (p *Profile) ConvertTo(dst conversion.Hub) error {
+	profile, ok := dst.(*atomixv1.Profile)
+	if !ok {
+		return fmt.Errorf("unsupported conversion to %T", dst)
+	}
+	profile.ObjectMeta = p.ObjectMeta
+	profile.Spec = convertProfileSpecTo(p.Spec)
+	return preserveStoreReferences(&profile.ObjectMeta, p.Spec)
+}
+
+// ConvertFrom converts the Profile from the v1 hub version
+func (p *Profile) ConvertFrom(src conversion.Hub) error {
+	profile, ok := src.(*atomixv1.Profile)
+	if !ok {
+		return fmt.Errorf("unsupported conversion from %T", src)
+	}
+	p.ObjectMeta = profile.ObjectMeta
+	p.Spec = convertProfileSpecFrom(profile.Spec)
+	return restoreStoreReferences(&p.ObjectMeta, &p.Spec)
+}
+
+// ConvertTo converts the ClusterProfile to the v1 hub version
+func (p *ClusterProfile) ConvertTo(dst conversion.Hub) error {
+	profile, ok := dst.(*atomixv1.ClusterProfile)
+	if !ok {
+		return fmt.Errorf("unsupported conversion to %T", dst)
+	}
+	profile.ObjectMeta = p.ObjectMeta
+	profile.Spec = convertProfileSpecTo(p.Spec)
+	return preserveStoreReferences(&profile.ObjectMeta, p.Spec)
+}
+
+// ConvertFrom converts the ClusterProfile from the v1 hub version
+func (p *ClusterProfile) ConvertFrom(src conversion.Hub) error {
+	profile, ok := src.(*atomixv1.ClusterProfile)
+	if !ok {
+		return fmt.Errorf("unsupported conversion from %T", src)
+	}
+	p.ObjectMeta = profile.ObjectMeta
+	p.Spec = convertProfileSpecFrom(profile.Spec)
+	return restoreStoreReferences(&p.ObjectMeta, &p.Spec)
+}
+
+func convertProfileSpecTo(spec ProfileSpec) atomixv1.ProfileSpec {
+	// Empty lists are preserved rather than converted to nil, which would be serialized as null
+	var bindings []atomixv1.ProfileBinding
+	if spec.Bindings != nil {
+		bindings = make([]atomixv1.ProfileBinding, 0, len(spec.Bindings))
+	}
+	for _, binding := range spec.Bindings {
+		var primitives []atomixv1.PrimitiveBindingRule
+		if binding.Primitives != nil {
+			primitives = make([]atomixv1.PrimitiveBindingRule, 0, len(binding.Primitives))
+		}
+		for _, rule := range binding.Primitives {
+			primitives = append(primitives, atomixv1.PrimitiveBindingRule{
+				Kinds:       rule.Kinds,
+				APIVersions: rule.APIVersions,
+				Names:       rule.Names,
+				Tags:        rule.Tags,
+			})
+		}
+		bindings = append(bindings, atomixv1.ProfileBinding{
+			Name: binding.Name,
+			Store: atomixv1.StoreReference{
+				Kind:      binding.Store.Kind,
+				Namespace: binding.Store.Namespace,
+				Name:      binding.Store.Name,
+			},
+			Primitives: primitives,
+		})
+	}
+	return atomixv1.ProfileSpec{
+		Bindings: bindings,
+	}
+}
+
+func convertProfileSpecFrom(spec atomixv1.ProfileSpec) ProfileSpec {
+	var bindings []ProfileBinding
+	if spec.Bindings != nil {
+		bindings = make([]ProfileBinding, 0, len(spec.Bindings))
+	}
+	for _, binding := range spec.Bindings {
+		var primitives []PrimitiveBindingRule
+		if binding.Primitives != nil {
+			primitives = make([]PrimitiveBindingRule, 0, len(binding.Primitives))
+		}
+		for _, rule := range binding.Primitives {
+			primitives = append(primitives, PrimitiveBindingRule{
+				Kinds:       rule.Kinds,
+				APIVersions: rule.APIVersions,
+				Names:       rule.Names,
+				Tags:        rule.Tags,
+			})
+		}
+		bindings = append(bindings, ProfileBinding{
+			Name: binding.Name,
+			Store: corev1.ObjectReference{
+				Kind:      binding.Store.Kind,
+				Namespace: binding.Store.Namespace,
+				Name:      binding.Store.Name,
+			},
+			Primitives: primitives,
+		})
+	}
+	return ProfileSpec{
+		Bindings: bindings,
+	}
+}
+
+// preserveStoreReferences records the fields of the spec's store references that are not represented in v1
+// in an annotation on the converted object, so they survive a round trip through v1
+func preserveStoreReferences(meta *metav1.ObjectMeta, spec ProfileSpec) error {
+	references := make(map[string]storeReferenceFields)
+	for _, binding := range spec.Bindings {
+		fields := storeReferenceFields{
+			APIVersion:      binding.Store.APIVersion,
+			UID:             binding.Store.UID,
+			ResourceVersion: binding.Store.ResourceVersion,
+			FieldPath:       binding.Store.FieldPath,
+		}
+		if fields != (storeReferenceFields{}) {
+			references[binding.Name] = fields
+		}
+	}
+
+	annotations := copyAnnotations(meta.Annotations)
+	delete(annotations, storeReferencesAnnotation)
+	if len(references) > 0 {
+		bytes, err := json.Marshal(references)
+		if err != nil {
+			return err
+		}
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[storeReferencesAnnotation] = string(bytes)
+	}
+	meta.Annotations = annotations
+	return nil
+}
+
+// restoreStoreReferences restores the fields of the spec's store references preserved by preserveStoreReferences
+func restoreStoreReferences(meta *metav1.ObjectMeta, spec *ProfileSpec) error {
+	value, ok := meta.Annotations[storeReferencesAnnotation]
+	if !ok {
+		return nil
+	}
+	references := make(map[string]storeReferenceFields)
+	if err := json.Unmarshal([]byte(value), &references); err != nil {
+		return err
+	}
+	for i, binding := range spec.Bindings {
+		if fields, ok := references[binding.Name]; ok {
+			binding.Store.APIVersion = fields.APIVersion
+			binding.Store.UID = fields.UID
+			binding.Store.ResourceVersion = fields.ResourceVersion
+			binding.Store.FieldPath = fields.FieldPath
+			spec.Bindings[i] = binding
+		}
+	}
+
+	annotations := copyAnnotations(meta.Annotations)
+	delete(annotations, storeReferencesAnnotation)
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	meta.Annotations = annotations
+	return nil
+}
+
+// copyAnnotations copies the given annotations, which are otherwise shared between the source and converted objects
+func copyAnnotations(annotations map[string]string) map[string]string {
+	if annotations == nil {
+		return nil
+	}
+	copied := make(map[string]string, len(annotations))
+	for key, value := range annotations {
+		copied[key] = value
+	}
+	return copied
+}
+
+// ConvertTo converts the Proxy to the v1 hub version
+func (p *Proxy) ConvertTo(dst conversion.Hub) error {
+	proxy, ok := dst.(*atomixv1.Proxy)
+	if !ok {
+		return fmt.Errorf("unsupported conversion to %T", dst)
+	}
+	proxy.ObjectMeta = p.ObjectMeta
+	proxy.Spec = atomixv1.ProxySpec{
+		Pod: p.Pod,
+		Profile: atomixv1.ProfileReference{
+			Kind: p.Profile.Kind,
+			Name: p.Profile.Name,
+		},
+	}
+	if p.Endpoint != nil {
+		proxy.Spec.Endpoint = &atomixv1.ProxyEndpoint{
+			Address:        p.Endpoint.Address,
+			RuntimeVersion: p.Endpoint.RuntimeVersion,
+		}
+		if p.Endpoint.TLS != nil {
+			proxy.Spec.Endpoint.TLS = &atomixv1.ProxyTLSConfig{
+				SecretName:         p.Endpoint.TLS.SecretName,
+				ServerName:         p.Endpoint.TLS.ServerName,
+				InsecureSkipVerify: p.Endpoint.TLS.InsecureSkipVerify,
+			}
+		}
+	}
+
+	var bindings []atomixv1.BindingStatus
+	if p.Status.Bindings != nil {
+		bindings = make([]atomixv1.BindingStatus, 0, len(p.Status.Bindings))
+	}
+	for _, binding := range p.Status.Bindings {
+		bindings = append(bindings, atomixv1.BindingStatus{
+			Name:    binding.Name,
+			State:   atomixv1.BindingState(binding.State),
+			Version: binding.Version,
+			Message: binding.Message,
+		})
+	}
+	proxy.Status = atomixv1.ProxyStatus{
+		Ready:          p.Status.Ready,
+		RuntimeVersion: p.Status.RuntimeVersion,
+		PodUID:         p.Status.PodUID,
+		Endpoint:       p.Status.Endpoint,
+		Conditions:     p.Status.Conditions,
+		Bindings:       bindings,
+	}
+	return nil
+}
+
+// ConvertFrom converts the Proxy from the v1 hub version
+func (p *Proxy) ConvertFrom(src conversion.Hub) error {
+	proxy, ok := src.(*atomixv1.Proxy)
+	if !ok {
+		return fmt.Errorf("unsupported conversion from %T", src)
+	}
+	p.ObjectMeta = proxy.ObjectMeta
+	p.Pod = proxy.Spec.Pod
+	p.Endpoint = nil
+	if proxy.Spec.Endpoint != nil {
+		p.Endpoint = &ProxyEndpoint{
+			Address:        proxy.Spec.Endpoint.Address,
+			RuntimeVersion: proxy.Spec.Endpoint.RuntimeVersion,
+		}
+		if proxy.Spec.Endpoint.TLS != nil {
+			p.Endpoint.TLS = &ProxyTLSConfig{
+				SecretName:         proxy.Spec.Endpoint.TLS.SecretName,
+				ServerName:         proxy.Spec.Endpoint.TLS.ServerName,
+				InsecureSkipVerify: proxy.Spec.Endpoint.TLS.InsecureSkipVerify,
+			}
+		}
+	}
+	p.Profile = ProfileReference{
+		Kind: proxy.Spec.Profile.Kind,
+		Name: proxy.Spec.Profile.Name,
+	}
+
+	var bindings []BindingStatus
+	if proxy.Status.Bindings != nil {
+		bindings = make([]BindingStatus, 0, len(proxy.Status.Bindings))
+	}
+	for _, binding := range proxy.Status.Bindings {
+		bindings = append(bindings, BindingStatus{
+			Name:    binding.Name,
+			State:   BindingState(binding.State),
+			Version: binding.Version,
+			Message: binding.Message,
+		})
+	}
+	p.Status = ProxyStatus{
+		Ready:          proxy.Status.Ready,
+		RuntimeVersion: proxy.Status.RuntimeVersion,
+		PodUID:         proxy.Status.PodUID,
+		Endpoint:       proxy.Status.Endpoint,
+		Conditions:     proxy.Status.Conditions,
+		Bindings:       bindings,
+	}
+	return nil
+}
+
+var _ conversion.Convertible = &Profile{}
+var _ conversion.Convertible = &ClusterProfile{}
+var _ conversion.Convertible = &Proxy{}
@@ -0,0 +1,295 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	atomixv1 "github.com/atomix/controller/pkg/apis/atomix/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"reflect"
+	"testing"
+)
+
+func newTestProfileSpec() ProfileSpec {
+	return ProfileSpec{
+		Bindings: []ProfileBinding{
+			{
+				Name: "local",
+				Store: corev1.ObjectReference{
+					Kind: "Store",
+					Name: "local",
+				},
+				Primitives: []PrimitiveBindingRule{
+					{
+						Kinds:       []string{"Map"},
+						APIVersions: []string{"v1"},
+						Names:       []string{"foo-*"},
+						Tags:        map[string]string{"persistent": "true"},
+					},
+				},
+			},
+			{
+				Name: "remote",
+				Store: corev1.ObjectReference{
+					Kind:            "Store",
+					Namespace:       "stores",
+					Name:            "remote",
+					APIVersion:      "atomix.io/v1beta1",
+					UID:             "1234",
+					ResourceVersion: "5",
+					FieldPath:       "spec",
+				},
+				Primitives: []PrimitiveBindingRule{},
+			},
+		},
+	}
+}
+
+func TestProfileSpecRoundTrip(t *testing.T) {
+	tests := []struct {
+		name           string
+		annotations    map[string]string
+		spec           ProfileSpec
+		wantAnnotation bool
+	}{
+		{
+			name: "nil bindings",
+		},
+		{
+			name: "empty bindings",
+			spec: ProfileSpec{Bindings: []ProfileBinding{}},
+		},
+		{
+			name:           "store reference fields",
+			annotations:    map[string]string{"foo": "bar"},
+			spec:           newTestProfileSpec(),
+			wantAnnotation: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			meta := metav1.ObjectMeta{
+				Namespace:   "test",
+				Name:        "test",
+				Annotations: test.annotations,
+			}
+
+			t.Run("Profile", func(t *testing.T) {
+				profile := &Profile{ObjectMeta: *meta.DeepCopy(), Spec: *test.spec.DeepCopy()}
+				hub := &atomixv1.Profile{}
+				if err := profile.ConvertTo(hub); err != nil {
+					t.Fatal(err)
+				}
+				assertStoreReferencesAnnotation(t, hub.ObjectMeta, test.wantAnnotation)
+				assertBindingsNil(t, hub.Spec.Bindings == nil, profile.Spec.Bindings == nil)
+
+				converted := &Profile{}
+				if err := converted.ConvertFrom(hub); err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(converted, profile) {
+					t.Errorf("round trip = %+v, want %+v", converted, profile)
+				}
+			})
+
+			t.Run("ClusterProfile", func(t *testing.T) {
+				profile := &ClusterProfile{ObjectMeta: *meta.DeepCopy(), Spec: *test.spec.DeepCopy()}
+				hub := &atomixv1.ClusterProfile{}
+				if err := profile.ConvertTo(hub); err != nil {
+					t.Fatal(err)
+				}
+				assertStoreReferencesAnnotation(t, hub.ObjectMeta, test.wantAnnotation)
+				assertBindingsNil(t, hub.Spec.Bindings == nil, profile.Spec.Bindings == nil)
+
+				converted := &ClusterProfile{}
+				if err := converted.ConvertFrom(hub); err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(converted, profile) {
+					t.Errorf("round trip = %+v, want %+v", converted, profile)
+				}
+			})
+		})
+	}
+}
+
+func TestHubProfileSpecRoundTrip(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		spec        atomixv1.ProfileSpec
+	}{
+		{
+			name: "nil bindings",
+		},
+		{
+			name: "empty bindings",
+			spec: atomixv1.ProfileSpec{Bindings: []atomixv1.ProfileBinding{}},
+		},
+		{
+			name: "bindings",
+			annotations: map[string]string{
+				"foo":                     "bar",
+				storeReferencesAnnotation: `{"remote":{"apiVersion":"atomix.io/v1beta1","uid":"1234"}}`,
+			},
+			spec: atomixv1.ProfileSpec{
+				Bindings: []atomixv1.ProfileBinding{
+					{
+						Name: "remote",
+						Store: atomixv1.StoreReference{
+							Kind:      "Store",
+							Namespace: "stores",
+							Name:      "remote",
+						},
+						Primitives: []atomixv1.PrimitiveBindingRule{
+							{
+								Kinds: []string{"Map"},
+								Names: []string{"*"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			meta := metav1.ObjectMeta{
+				Name:        "test",
+				Annotations: test.annotations,
+			}
+
+			t.Run("Profile", func(t *testing.T) {
+				hub := &atomixv1.Profile{ObjectMeta: *meta.DeepCopy(), Spec: *test.spec.DeepCopy()}
+				profile := &Profile{}
+				if err := profile.ConvertFrom(hub); err != nil {
+					t.Fatal(err)
+				}
+				if _, ok := profile.Annotations[storeReferencesAnnotation]; ok {
+					t.Errorf("annotation %s was not removed", storeReferencesAnnotation)
+				}
+				converted := &atomixv1.Profile{}
+				if err := profile.ConvertTo(converted); err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(converted, hub) {
+					t.Errorf("round trip = %+v, want %+v", converted, hub)
+				}
+			})
+
+			t.Run("ClusterProfile", func(t *testing.T) {
+				hub := &atomixv1.ClusterProfile{ObjectMeta: *meta.DeepCopy(), Spec: *test.spec.DeepCopy()}
+				profile := &ClusterProfile{}
+				if err := profile.ConvertFrom(hub); err != nil {
+					t.Fatal(err)
+				}
+				converted := &atomixv1.ClusterProfile{}
+				if err := profile.ConvertTo(converted); err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(converted, hub) {
+					t.Errorf("round trip = %+v, want %+v", converted, hub)
+				}
+			})
+		})
+	}
+}
+
+func TestProxyRoundTrip(t *testing.T) {
+	now := metav1.Now()
+	tests := []struct {
+		name  string
+		proxy Proxy
+	}{
+		{
+			name: "pod",
+			proxy: Proxy{
+				Pod:     corev1.LocalObjectReference{Name: "pod"},
+				Profile: ProfileReference{Name: "profile"},
+				Status: ProxyStatus{
+					Ready:          true,
+					RuntimeVersion: "v0.7.0",
+					PodUID:         "1234",
+					Endpoint:       "10.0.0.1:5679",
+					Conditions: []metav1.Condition{
+						{Type: ProxyPodFound, Status: metav1.ConditionTrue, LastTransitionTime: now, Reason: "Found"},
+					},
+					Bindings: []BindingStatus{
+						{Name: "local", State: BindingBound, Version: "1"},
+						{Name: "remote", State: BindingDenied, Message: "denied"},
+					},
+				},
+			},
+		},
+		{
+			name: "endpoint",
+			proxy: Proxy{
+				Endpoint: &ProxyEndpoint{
+					Address:        "proxy.example.com:5679",
+					RuntimeVersion: "v0.7.0",
+					TLS: &ProxyTLSConfig{
+						SecretName:         "proxy-tls",
+						ServerName:         "proxy.example.com",
+						InsecureSkipVerify: true,
+					},
+				},
+				Profile: ProfileReference{Kind: "ClusterProfile", Name: "profile"},
+			},
+		},
+		{
+			name: "empty status bindings",
+			proxy: Proxy{
+				Pod:     corev1.LocalObjectReference{Name: "pod"},
+				Profile: ProfileReference{Name: "profile"},
+				Status: ProxyStatus{
+					Bindings: []BindingStatus{},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			proxy := test.proxy.DeepCopy()
+			proxy.ObjectMeta = metav1.ObjectMeta{Namespace: "test", Name: "test"}
+
+			hub := &atomixv1.Proxy{}
+			if err := proxy.ConvertTo(hub); err != nil {
+				t.Fatal(err)
+			}
+			assertBindingsNil(t, hub.Status.Bindings == nil, proxy.Status.Bindings == nil)
+
+			converted := &Proxy{}
+			if err := converted.ConvertFrom(hub); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(converted, proxy) {
+				t.Errorf("round trip = %+v, want %+v", converted, proxy)
+			}
+
+			// Converting the v1 proxy back to v1 yields the same proxy
+			roundTrip := &atomixv1.Proxy{}
+			if err := converted.ConvertTo(roundTrip); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(roundTrip, hub) {
+				t.Errorf("round trip = %+v, want %+v", roundTrip, hub)
+			}
+		})
+	}
+}
+
+func assertStoreReferencesAnnotation(t *testing.T, meta metav1.ObjectMeta, want bool) {
+	t.Helper()
+	if _, ok := meta.Annotations[storeReferencesAnnotation]; ok != want {
+		t.Errorf("annotation %s present = %t, want %t", storeReferencesAnnotation, ok, want)
+	}
+}
+
+func assertBindingsNil(t *testing.T, got, want bool) {
+	t.Helper()
+	if got != want {
+		t.Errorf("converted bindings nil = %t, want %t", got, want)
+	}
+}
@@ -38,8 +38,13 @@ var (
 // Adds the list of known types to Scheme.
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion, &Store{}, &StoreList{})
+	scheme.AddKnownTypes(SchemeGroupVersion, &StoreGrant{}, &StoreGrantList{})
+	scheme.AddKnownTypes(SchemeGroupVersion, &ClusterStore{}, &ClusterStoreList{})
+	scheme.AddKnownTypes(SchemeGroupVersion, &Driver{}, &DriverList{})
 	scheme.AddKnownTypes(SchemeGroupVersion, &Profile{}, &ProfileList{})
+	scheme.AddKnownTypes(SchemeGroupVersion, &ClusterProfile{}, &ClusterProfileList{})
 	scheme.AddKnownTypes(SchemeGroupVersion, &Proxy{}, &ProxyList{})
+	scheme.AddKnownTypes(SchemeGroupVersion, &ProxyPool{}, &ProxyPoolList{})
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
 }
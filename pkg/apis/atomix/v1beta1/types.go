@@ -6,12 +6,20 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Namespaced
+// +kubebuilder:printcolumn:name="Driver",type=string,JSONPath=`.spec.driver.name`
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.spec.driver.version`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // Store is a specification for a Store resource
 type Store struct {
@@ -23,12 +31,21 @@ type Store struct {
 
 // StoreSpec is the spec for a Store resource
 type StoreSpec struct {
-	Driver Driver               `json:"driver"`
+	// Driver is the driver version used to connect to the store
+	Driver DriverReference `json:"driver"`
+	// Config is the configuration for the runtime driver
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
 	Config runtime.RawExtension `json:"config"`
 }
 
-type Driver struct {
-	Name    string `json:"name"`
+// DriverReference is a reference to a version of a Driver
+type DriverReference struct {
+	// Name is the name of the Driver
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Version is the name of the driver version
+	// +kubebuilder:validation:MinLength=1
 	Version string `json:"version"`
 }
 
@@ -44,6 +61,143 @@ type StoreList struct {
 
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Namespaced
+// +kubebuilder:printcolumn:name="Namespaces",type=string,JSONPath=`.spec.namespaces`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// StoreGrant grants Profiles in other namespaces permission to bind to Stores in the grant's namespace
+type StoreGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec StoreGrantSpec `json:"spec"`
+}
+
+// StoreGrantSpec is the spec for a StoreGrant resource
+type StoreGrantSpec struct {
+	// Namespaces is the list of namespaces permitted to bind to the Stores. The wildcard '*' matches all namespaces.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:XValidation:rule="self.all(n, n != '')",message="namespaces must not be empty"
+	Namespaces []string `json:"namespaces"`
+	// Stores is the list of Stores to which the grant applies. If empty, the grant applies to all Stores in the namespace.
+	Stores []corev1.LocalObjectReference `json:"stores,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// StoreGrantList is a list of StoreGrant resources
+type StoreGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []StoreGrant `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Driver",type=string,JSONPath=`.spec.driver.name`
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.spec.driver.version`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ClusterStore is a specification for a cluster-scoped Store resource
+type ClusterStore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec StoreSpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterStoreList is a list of ClusterStore resources
+type ClusterStoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ClusterStore `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Versions",type=string,JSONPath=`.spec.versions[*].name`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Driver is a specification for a Driver resource
+type Driver struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec DriverSpec `json:"spec"`
+}
+
+// DriverSpec is the spec for a Driver resource
+type DriverSpec struct {
+	// Versions is the list of supported versions of the driver
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=name
+	Versions []DriverVersion `json:"versions"`
+}
+
+// DriverVersion describes a supported version of a driver
+type DriverVersion struct {
+	// Name is the name of the driver version
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Schema is the JSON Schema used to validate and default the configuration of Stores using this version
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Schema *apiextensionsv1.JSONSchemaProps `json:"schema,omitempty"`
+	// Defaults is the default configuration for Stores using this version
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Defaults runtime.RawExtension `json:"defaults,omitempty"`
+	// RuntimeVersions is the list of proxy runtime versions with which this version is compatible
+	RuntimeVersions []string `json:"runtimeVersions,omitempty"`
+	// Plugin describes how the driver plugin is delivered to proxies
+	Plugin *DriverPlugin `json:"plugin,omitempty"`
+}
+
+// DriverPlugin describes an image from which a driver plugin is delivered to proxies
+type DriverPlugin struct {
+	// Image is the image containing the driver plugin
+	// +kubebuilder:validation:MinLength=1
+	Image string `json:"image"`
+	// ImagePullPolicy is the pull policy for the plugin image
+	// +kubebuilder:validation:Enum=Always;Never;IfNotPresent
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// Path is the path to the driver plugin within the image
+	// +kubebuilder:validation:MinLength=1
+	Path string `json:"path"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DriverList is a list of Driver resources
+type DriverList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Driver `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Namespaced
+// +kubebuilder:printcolumn:name="Bindings",type=string,JSONPath=`.spec.bindings[*].name`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // Profile is a specification for a Profile resource
 type Profile struct {
@@ -55,20 +209,40 @@ type Profile struct {
 
 // ProfileSpec is the spec for a Profile resource
 type ProfileSpec struct {
+	// Bindings is the list of bindings of primitives to stores
+	// +listType=map
+	// +listMapKey=name
 	Bindings []ProfileBinding `json:"bindings"`
 }
 
+// ProfileBinding binds primitives matching a set of rules to a Store or ClusterStore
 type ProfileBinding struct {
-	Name       string                 `json:"name"`
-	Store      corev1.ObjectReference `json:"store"`
+	// Name is the name of the binding
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Store is a reference to the Store or ClusterStore to which primitives are bound
+	// +kubebuilder:validation:XValidation:rule="has(self.name) && self.name != ''",message="store name is required"
+	// +kubebuilder:validation:XValidation:rule="!has(self.kind) || self.kind in ['Store', 'ClusterStore']",message="store kind must be one of 'Store' or 'ClusterStore'"
+	// +kubebuilder:validation:XValidation:rule="!has(self.kind) || self.kind != 'ClusterStore' || !has(self.namespace) || self.namespace == ''",message="ClusterStore references must not specify a namespace"
+	Store corev1.ObjectReference `json:"store"`
+	// Primitives is the list of rules matching primitives bound to the store
 	Primitives []PrimitiveBindingRule `json:"primitives"`
 }
 
+// PrimitiveBindingRule matches primitives by kind, API version, name and tags
 type PrimitiveBindingRule struct {
-	Kinds       []string          `json:"kinds"`
-	APIVersions []string          `json:"apiVersions"`
-	Names       []string          `json:"names"`
-	Tags        map[string]string `json:"tags"`
+	// Kinds is the list of primitive kinds matched by the rule
+	// +kubebuilder:validation:MinItems=1
+	Kinds []string `json:"kinds"`
+	// APIVersions is the list of primitive API versions matched by the rule
+	// +kubebuilder:validation:MinItems=1
+	APIVersions []string `json:"apiVersions"`
+	// Names is the list of primitive names matched by the rule
+	// +optional
+	Names []string `json:"names"`
+	// Tags is the set of primitive tags matched by the rule
+	// +optional
+	Tags map[string]string `json:"tags"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -82,34 +256,151 @@ type ProfileList struct {
 }
 
 // +genclient
+// +genclient:nonNamespaced
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Bindings",type=string,JSONPath=`.spec.bindings[*].name`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ClusterProfile is a specification for a cluster-scoped Profile resource
+type ClusterProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ProfileSpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterProfileList is a list of ClusterProfile resources
+type ClusterProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ClusterProfile `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Namespaced
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Pod",type=string,JSONPath=`.pod.name`
+// +kubebuilder:printcolumn:name="Endpoint",type=string,JSONPath=`.endpoint.address`,priority=1
+// +kubebuilder:printcolumn:name="Profile",type=string,JSONPath=`.profile.name`
+// +kubebuilder:printcolumn:name="Ready",type=boolean,JSONPath=`.status.ready`
+// +kubebuilder:printcolumn:name="Runtime",type=string,JSONPath=`.status.runtimeVersion`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:validation:XValidation:rule="(has(self.pod) && has(self.pod.name) && size(self.pod.name) != 0) != has(self.endpoint)",message="exactly one of pod or endpoint is required"
 
 // Proxy is a specification for a Proxy resource
 type Proxy struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Pod     corev1.LocalObjectReference `json:"pod"`
-	Profile corev1.LocalObjectReference `json:"profile"`
-	Status  ProxyStatus                 `json:"status"`
+	// Pod is a reference to the pod into which the proxy is injected
+	// +optional
+	Pod corev1.LocalObjectReference `json:"pod"`
+	// Endpoint is the control endpoint of a proxy running outside the cluster
+	// +optional
+	Endpoint *ProxyEndpoint `json:"endpoint,omitempty"`
+	// Profile is a reference to the proxy's Profile or ClusterProfile
+	Profile ProfileReference `json:"profile"`
+	// Status is the observed state of the proxy
+	// +optional
+	Status ProxyStatus `json:"status"`
 }
 
+// ProxyEndpoint is the control endpoint of a proxy running outside the cluster
+type ProxyEndpoint struct {
+	// Address is the host:port of the proxy's control API
+	// +kubebuilder:validation:MinLength=1
+	Address string `json:"address"`
+	// RuntimeVersion is the runtime version of the proxy
+	// +optional
+	RuntimeVersion string `json:"runtimeVersion,omitempty"`
+	// TLS is the TLS configuration for connections to the proxy
+	// If unset, connections to the proxy are not secured.
+	// +optional
+	TLS *ProxyTLSConfig `json:"tls,omitempty"`
+}
+
+// ProxyTLSConfig is the TLS configuration for connections to an external proxy
+type ProxyTLSConfig struct {
+	// SecretName is the name of a Secret in the proxy's namespace containing the client certificate
+	// and key (tls.crt and tls.key) and the CA certificate (ca.crt) with which to verify the proxy
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+	// ServerName is the name used to verify the proxy's certificate, defaulting to the address host
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+	// InsecureSkipVerify disables verification of the proxy's certificate
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// ProfileReference is a reference to a Profile or ClusterProfile
+type ProfileReference struct {
+	// Kind is the kind of the profile
+	// +kubebuilder:validation:Enum=Profile;ClusterProfile
+	// +kubebuilder:default=Profile
+	Kind string `json:"kind,omitempty"`
+	// Name is the name of the profile
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// ProxyStatus is the observed state of a Proxy
 type ProxyStatus struct {
-	Ready    bool            `json:"ready"`
+	// Ready indicates whether all of the proxy's bindings are bound
+	// +optional
+	Ready bool `json:"ready"`
+	// RuntimeVersion is the runtime version of the proxy
+	RuntimeVersion string `json:"runtimeVersion,omitempty"`
+	// PodUID is the UID of the pod to which the proxy's bindings are connected
+	PodUID types.UID `json:"podUID,omitempty"`
+	// Endpoint is the address of the external or node proxy to which the proxy's bindings are connected
+	Endpoint string `json:"endpoint,omitempty"`
+	// Conditions is the list of observed conditions of the proxy
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Bindings is the status of the proxy's bindings
+	// +optional
 	Bindings []BindingStatus `json:"bindings"`
 }
 
+// ProxyPodFound is the type of the Proxy condition indicating whether the proxy's pod exists
+const ProxyPodFound = "PodFound"
+
+// BindingState is the state of a proxy binding
+// +kubebuilder:validation:Enum=Unbound;Bound;Denied;Incompatible
 type BindingState string
 
 const (
-	BindingUnbound BindingState = "Unbound"
-	BindingBound   BindingState = "Bound"
+	BindingUnbound      BindingState = "Unbound"
+	BindingBound        BindingState = "Bound"
+	BindingDenied       BindingState = "Denied"
+	BindingIncompatible BindingState = "Incompatible"
 )
 
+// BindingStatus is the status of a proxy binding
 type BindingStatus struct {
-	Name    string       `json:"name"`
-	State   BindingState `json:"state"`
-	Version string       `json:"version"`
+	// Name is the name of the binding
+	Name string `json:"name"`
+	// State is the state of the binding
+	// +kubebuilder:default=Unbound
+	// +optional
+	State BindingState `json:"state"`
+	// Version is the resource version of the store to which the binding is connected
+	// +optional
+	Version string `json:"version"`
+	// Message is a human readable message describing the state of the binding
+	Message string `json:"message,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -121,3 +412,63 @@ type ProxyList struct {
 
 	Items []Proxy `json:"items"`
 }
+
+// +genclient
+// +genclient:method=GetScale,verb=get,subresource=scale,result=k8s.io/api/autoscaling/v1.Scale
+// +genclient:method=UpdateScale,verb=update,subresource=scale,input=k8s.io/api/autoscaling/v1.Scale,result=k8s.io/api/autoscaling/v1.Scale
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Namespaced
+// +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
+// +kubebuilder:printcolumn:name="Profile",type=string,JSONPath=`.spec.profile.name`
+// +kubebuilder:printcolumn:name="Replicas",type=integer,JSONPath=`.spec.replicas`
+// +kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=`.status.readyReplicas`
+// +kubebuilder:printcolumn:name="Service",type=string,JSONPath=`.status.service`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ProxyPool is a specification for a pool of standalone proxies bound to a profile and exposed by a Service
+type ProxyPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ProxyPoolSpec `json:"spec"`
+	// +optional
+	Status ProxyPoolStatus `json:"status,omitempty"`
+}
+
+// ProxyPoolSpec is the spec for a ProxyPool resource
+type ProxyPoolSpec struct {
+	// Profile is a reference to the Profile or ClusterProfile to which the pool's proxies are bound
+	Profile ProfileReference `json:"profile"`
+	// Replicas is the number of proxies in the pool
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+}
+
+// ProxyPoolStatus is the observed state of a ProxyPool
+type ProxyPoolStatus struct {
+	// Replicas is the number of proxies in the pool
+	// +optional
+	Replicas int32 `json:"replicas"`
+	// ReadyReplicas is the number of proxies in the pool with all bindings bound
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas"`
+	// Selector is the label selector for the pool's pods
+	Selector string `json:"selector,omitempty"`
+	// Service is the name of the Service through which clients connect to the pool
+	Service string `json:"service,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ProxyPoolList is a list of ProxyPool resources
+type ProxyPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ProxyPool `json:"items"`
+}
@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	clientset "github.com/atomix/controller/pkg/client/clientset/versioned"
+	atomixv1 "github.com/atomix/controller/pkg/client/clientset/versioned/typed/atomix/v1"
+	fakeatomixv1 "github.com/atomix/controller/pkg/client/clientset/versioned/typed/atomix/v1/fake"
+	atomixv1beta1 "github.com/atomix/controller/pkg/client/clientset/versioned/typed/atomix/v1beta1"
+	fakeatomixv1beta1 "github.com/atomix/controller/pkg/client/clientset/versioned/typed/atomix/v1beta1/fake"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/discovery"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/testing"
+)
+
+// NewSimpleClientset returns a clientset that will respond with the provided objects.
+// It's backed by a very simple object tracker that processes creates, updates and deletions as-is,
+// without applying any validations and/or defaults. It shouldn't be considered a replacement
+// for a real clientset and is mostly useful in simple unit tests.
+func NewSimpleClientset(objects ...runtime.Object) *Clientset {
+	o := testing.NewObjectTracker(scheme, codecs.UniversalDecoder())
+	for _, obj := range objects {
+		if err := o.Add(obj); err != nil {
+			panic(err)
+		}
+	}
+
+	cs := &Clientset{tracker: o}
+	cs.discovery = &fakediscovery.FakeDiscovery{Fake: &cs.Fake}
+	cs.AddReactor("*", "*", testing.ObjectReaction(o))
+	cs.AddWatchReactor("*", func(action testing.Action) (handled bool, ret watch.Interface, err error) {
+		gvr := action.GetResource()
+		ns := action.GetNamespace()
+		watch, err := o.Watch(gvr, ns)
+		if err != nil {
+			return false, nil, err
+		}
+		return true, watch, nil
+	})
+
+	return cs
+}
+
+// Clientset implements clientset.Interface. Meant to be embedded into a
+// struct to get a default implementation. This makes faking out just the method
+// you want to test easier.
+type Clientset struct {
+	testing.Fake
+	discovery *fakediscovery.FakeDiscovery
+	tracker   testing.ObjectTracker
+}
+
+func (c *Clientset) Discovery() discovery.DiscoveryInterface {
+	return c.discovery
+}
+
+func (c *Clientset) Tracker() testing.ObjectTracker {
+	return c.tracker
+}
+
+var (
+	_ clientset.Interface = &Clientset{}
+	_ testing.FakeClient  = &Clientset{}
+)
+
+// AtomixV1beta1 retrieves the AtomixV1beta1Client
+func (c *Clientset) AtomixV1beta1() atomixv1beta1.AtomixV1beta1Interface {
+	return &fakeatomixv1beta1.FakeAtomixV1beta1{Fake: &c.Fake}
+}
+
+// AtomixV1 retrieves the AtomixV1Client
+func (c *Clientset) AtomixV1() atomixv1.AtomixV1Interface {
+	return &fakeatomixv1.FakeAtomixV1{Fake: &c.Fake}
+}
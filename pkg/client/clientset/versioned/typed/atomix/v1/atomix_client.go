@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"net/http"
+
+	v1 "github.com/atomix/controller/pkg/apis/atomix/v1"
+	"github.com/atomix/controller/pkg/client/clientset/versioned/scheme"
+	rest "k8s.io/client-go/rest"
+)
+
+type AtomixV1Interface interface {
+	RESTClient() rest.Interface
+	ClusterProfilesGetter
+	ClusterStoresGetter
+	DriversGetter
+	ProfilesGetter
+	ProxiesGetter
+	ProxyPoolsGetter
+	StoresGetter
+	StoreGrantsGetter
+}
+
+// AtomixV1Client is used to interact with features provided by the atomix.io group.
+type AtomixV1Client struct {
+	restClient rest.Interface
+}
+
+func (c *AtomixV1Client) ClusterProfiles() ClusterProfileInterface {
+	return newClusterProfiles(c)
+}
+
+func (c *AtomixV1Client) ClusterStores() ClusterStoreInterface {
+	return newClusterStores(c)
+}
+
+func (c *AtomixV1Client) Drivers() DriverInterface {
+	return newDrivers(c)
+}
+
+func (c *AtomixV1Client) Profiles(namespace string) ProfileInterface {
+	return newProfiles(c, namespace)
+}
+
+func (c *AtomixV1Client) Proxies(namespace string) ProxyInterface {
+	return newProxies(c, namespace)
+}
+
+func (c *AtomixV1Client) ProxyPools(namespace string) ProxyPoolInterface {
+	return newProxyPools(c, namespace)
+}
+
+func (c *AtomixV1Client) Stores(namespace string) StoreInterface {
+	return newStores(c, namespace)
+}
+
+func (c *AtomixV1Client) StoreGrants(namespace string) StoreGrantInterface {
+	return newStoreGrants(c, namespace)
+}
+
+// NewForConfig creates a new AtomixV1Client for the given config.
+// NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
+// where httpClient was generated with rest.HTTPClientFor(c).
+func NewForConfig(c *rest.Config) (*AtomixV1Client, error) {
+	config := *c
+	if err := setConfigDefaults(&config); err != nil {
+		return nil, err
+	}
+	httpClient, err := rest.HTTPClientFor(&config)
+	if err != nil {
+		return nil, err
+	}
+	return NewForConfigAndClient(&config, httpClient)
+}
+
+// NewForConfigAndClient creates a new AtomixV1Client for the given config and http client.
+// Note the http client provided takes precedence over the configured transport values.
+func NewForConfigAndClient(c *rest.Config, h *http.Client) (*AtomixV1Client, error) {
+	config := *c
+	if err := setConfigDefaults(&config); err != nil {
+		return nil, err
+	}
+	client, err := rest.RESTClientForConfigAndClient(&config, h)
+	if err != nil {
+		return nil, err
+	}
+	return &AtomixV1Client{client}, nil
+}
+
+// NewForConfigOrDie creates a new AtomixV1Client for the given config and
+// panics if there is an error in the config.
+func NewForConfigOrDie(c *rest.Config) *AtomixV1Client {
+	client, err := NewForConfig(c)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// New creates a new AtomixV1Client for the given RESTClient.
+func New(c rest.Interface) *AtomixV1Client {
+	return &AtomixV1Client{c}
+}
+
+func setConfigDefaults(config *rest.Config) error {
+	gv := v1.SchemeGroupVersion
+	config.GroupVersion = &gv
+	config.APIPath = "/apis"
+	config.NegotiatedSerializer = scheme.Codecs.WithoutConversion()
+
+	if config.UserAgent == "" {
+		config.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+
+	return nil
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *AtomixV1Client) RESTClient() rest.Interface {
+	if c == nil {
+		return nil
+	}
+	return c.restClient
+}
@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/atomix/controller/pkg/apis/atomix/v1"
+	scheme "github.com/atomix/controller/pkg/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ClusterProfilesGetter has a method to return a ClusterProfileInterface.
+// A group's client should implement this interface.
+type ClusterProfilesGetter interface {
+	ClusterProfiles() ClusterProfileInterface
+}
+
+// ClusterProfileInterface has methods to work with ClusterProfile resources.
+type ClusterProfileInterface interface {
+	Create(ctx context.Context, clusterProfile *v1.ClusterProfile, opts metav1.CreateOptions) (*v1.ClusterProfile, error)
+	Update(ctx context.Context, clusterProfile *v1.ClusterProfile, opts metav1.UpdateOptions) (*v1.ClusterProfile, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.ClusterProfile, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.ClusterProfileList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ClusterProfile, err error)
+	ClusterProfileExpansion
+}
+
+// clusterProfiles implements ClusterProfileInterface
+type clusterProfiles struct {
+	client rest.Interface
+}
+
+// newClusterProfiles returns a ClusterProfiles
+func newClusterProfiles(c *AtomixV1Client) *clusterProfiles {
+	return &clusterProfiles{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the clusterProfile, and returns the corresponding clusterProfile object, and an error if there is any.
+func (c *clusterProfiles) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.ClusterProfile, err error) {
+	result = &v1.ClusterProfile{}
+	err = c.client.Get().
+		Resource("clusterprofiles").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ClusterProfiles that match those selectors.
+func (c *clusterProfiles) List(ctx context.Context, opts metav1.ListOptions) (result *v1.ClusterProfileList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.ClusterProfileList{}
+	err = c.client.Get().
+		Resource("clusterprofiles").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested clusterProfiles.
+func (c *clusterProfiles) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("clusterprofiles").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a clusterProfile and creates it.  Returns the server's representation of the clusterProfile, and an error, if there is any.
+func (c *clusterProfiles) Create(ctx context.Context, clusterProfile *v1.ClusterProfile, opts metav1.CreateOptions) (result *v1.ClusterProfile, err error) {
+	result = &v1.ClusterProfile{}
+	err = c.client.Post().
+		Resource("clusterprofiles").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterProfile).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a clusterProfile and updates it. Returns the server's representation of the clusterProfile, and an error, if there is any.
+func (c *clusterProfiles) Update(ctx context.Context, clusterProfile *v1.ClusterProfile, opts metav1.UpdateOptions) (result *v1.ClusterProfile, err error) {
+	result = &v1.ClusterProfile{}
+	err = c.client.Put().
+		Resource("clusterprofiles").
+		Name(clusterProfile.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterProfile).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the clusterProfile and deletes it. Returns an error if one occurs.
+func (c *clusterProfiles) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("clusterprofiles").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *clusterProfiles) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("clusterprofiles").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched clusterProfile.
+func (c *clusterProfiles) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ClusterProfile, err error) {
+	result = &v1.ClusterProfile{}
+	err = c.client.Patch(pt).
+		Resource("clusterprofiles").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
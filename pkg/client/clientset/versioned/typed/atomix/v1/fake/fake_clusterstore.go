@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	atomixv1 "github.com/atomix/controller/pkg/apis/atomix/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeClusterStores implements ClusterStoreInterface
+type FakeClusterStores struct {
+	Fake *FakeAtomixV1
+}
+
+var clusterstoresResource = schema.GroupVersionResource{Group: "atomix.io", Version: "v1", Resource: "clusterstores"}
+
+var clusterstoresKind = schema.GroupVersionKind{Group: "atomix.io", Version: "v1", Kind: "ClusterStore"}
+
+// Get takes name of the clusterStore, and returns the corresponding clusterStore object, and an error if there is any.
+func (c *FakeClusterStores) Get(ctx context.Context, name string, options v1.GetOptions) (result *atomixv1.ClusterStore, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(clusterstoresResource, name), &atomixv1.ClusterStore{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*atomixv1.ClusterStore), err
+}
+
+// List takes label and field selectors, and returns the list of ClusterStores that match those selectors.
+func (c *FakeClusterStores) List(ctx context.Context, opts v1.ListOptions) (result *atomixv1.ClusterStoreList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(clusterstoresResource, clusterstoresKind, opts), &atomixv1.ClusterStoreList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &atomixv1.ClusterStoreList{ListMeta: obj.(*atomixv1.ClusterStoreList).ListMeta}
+	for _, item := range obj.(*atomixv1.ClusterStoreList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested clusterStores.
+func (c *FakeClusterStores) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(clusterstoresResource, opts))
+}
+
+// Create takes the representation of a clusterStore and creates it.  Returns the server's representation of the clusterStore, and an error, if there is any.
+func (c *FakeClusterStores) Create(ctx context.Context, clusterStore *atomixv1.ClusterStore, opts v1.CreateOptions) (result *atomixv1.ClusterStore, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(clusterstoresResource, clusterStore), &atomixv1.ClusterStore{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*atomixv1.ClusterStore), err
+}
+
+// Update takes the representation of a clusterStore and updates it. Returns the server's representation of the clusterStore, and an error, if there is any.
+func (c *FakeClusterStores) Update(ctx context.Context, clusterStore *atomixv1.ClusterStore, opts v1.UpdateOptions) (result *atomixv1.ClusterStore, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(clusterstoresResource, clusterStore), &atomixv1.ClusterStore{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*atomixv1.ClusterStore), err
+}
+
+// Delete takes name of the clusterStore and deletes it. Returns an error if one occurs.
+func (c *FakeClusterStores) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(clusterstoresResource, name, opts), &atomixv1.ClusterStore{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeClusterStores) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(clusterstoresResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &atomixv1.ClusterStoreList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched clusterStore.
+func (c *FakeClusterStores) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *atomixv1.ClusterStore, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clusterstoresResource, name, pt, data, subresources...), &atomixv1.ClusterStore{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*atomixv1.ClusterStore), err
+}
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	atomixv1 "github.com/atomix/controller/pkg/apis/atomix/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeDrivers implements DriverInterface
+type FakeDrivers struct {
+	Fake *FakeAtomixV1
+}
+
+var driversResource = schema.GroupVersionResource{Group: "atomix.io", Version: "v1", Resource: "drivers"}
+
+var driversKind = schema.GroupVersionKind{Group: "atomix.io", Version: "v1", Kind: "Driver"}
+
+// Get takes name of the driver, and returns the corresponding driver object, and an error if there is any.
+func (c *FakeDrivers) Get(ctx context.Context, name string, options v1.GetOptions) (result *atomixv1.Driver, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(driversResource, name), &atomixv1.Driver{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*atomixv1.Driver), err
+}
+
+// List takes label and field selectors, and returns the list of Drivers that match those selectors.
+func (c *FakeDrivers) List(ctx context.Context, opts v1.ListOptions) (result *atomixv1.DriverList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(driversResource, driversKind, opts), &atomixv1.DriverList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &atomixv1.DriverList{ListMeta: obj.(*atomixv1.DriverList).ListMeta}
+	for _, item := range obj.(*atomixv1.DriverList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested drivers.
+func (c *FakeDrivers) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(driversResource, opts))
+}
+
+// Create takes the representation of a driver and creates it.  Returns the server's representation of the driver, and an error, if there is any.
+func (c *FakeDrivers) Create(ctx context.Context, driver *atomixv1.Driver, opts v1.CreateOptions) (result *atomixv1.Driver, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(driversResource, driver), &atomixv1.Driver{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*atomixv1.Driver), err
+}
+
+// Update takes the representation of a driver and updates it. Returns the server's representation of the driver, and an error, if there is any.
+func (c *FakeDrivers) Update(ctx context.Context, driver *atomixv1.Driver, opts v1.UpdateOptions) (result *atomixv1.Driver, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(driversResource, driver), &atomixv1.Driver{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*atomixv1.Driver), err
+}
+
+// Delete takes name of the driver and deletes it. Returns an error if one occurs.
+func (c *FakeDrivers) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(driversResource, name, opts), &atomixv1.Driver{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeDrivers) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(driversResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &atomixv1.DriverList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched driver.
+func (c *FakeDrivers) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *atomixv1.Driver, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(driversResource, name, pt, data, subresources...), &atomixv1.Driver{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*atomixv1.Driver), err
+}
@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	atomixv1 "github.com/atomix/controller/pkg/apis/atomix/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeProxies implements ProxyInterface
+type FakeProxies struct {
+	Fake *FakeAtomixV1
+	ns   string
+}
+
+var proxiesResource = schema.GroupVersionResource{Group: "atomix.io", Version: "v1", Resource: "proxies"}
+
+var proxiesKind = schema.GroupVersionKind{Group: "atomix.io", Version: "v1", Kind: "Proxy"}
+
+// Get takes name of the proxy, and returns the corresponding proxy object, and an error if there is any.
+func (c *FakeProxies) Get(ctx context.Context, name string, options v1.GetOptions) (result *atomixv1.Proxy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(proxiesResource, c.ns, name), &atomixv1.Proxy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*atomixv1.Proxy), err
+}
+
+// List takes label and field selectors, and returns the list of Proxies that match those selectors.
+func (c *FakeProxies) List(ctx context.Context, opts v1.ListOptions) (result *atomixv1.ProxyList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(proxiesResource, proxiesKind, c.ns, opts), &atomixv1.ProxyList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &atomixv1.ProxyList{ListMeta: obj.(*atomixv1.ProxyList).ListMeta}
+	for _, item := range obj.(*atomixv1.ProxyList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested proxies.
+func (c *FakeProxies) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(proxiesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a proxy and creates it.  Returns the server's representation of the proxy, and an error, if there is any.
+func (c *FakeProxies) Create(ctx context.Context, proxy *atomixv1.Proxy, opts v1.CreateOptions) (result *atomixv1.Proxy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(proxiesResource, c.ns, proxy), &atomixv1.Proxy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*atomixv1.Proxy), err
+}
+
+// Update takes the representation of a proxy and updates it. Returns the server's representation of the proxy, and an error, if there is any.
+func (c *FakeProxies) Update(ctx context.Context, proxy *atomixv1.Proxy, opts v1.UpdateOptions) (result *atomixv1.Proxy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(proxiesResource, c.ns, proxy), &atomixv1.Proxy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*atomixv1.Proxy), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeProxies) UpdateStatus(ctx context.Context, proxy *atomixv1.Proxy, opts v1.UpdateOptions) (*atomixv1.Proxy, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(proxiesResource, "status", c.ns, proxy), &atomixv1.Proxy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*atomixv1.Proxy), err
+}
+
+// Delete takes name of the proxy and deletes it. Returns an error if one occurs.
+func (c *FakeProxies) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(proxiesResource, c.ns, name, opts), &atomixv1.Proxy{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeProxies) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(proxiesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &atomixv1.ProxyList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched proxy.
+func (c *FakeProxies) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *atomixv1.Proxy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(proxiesResource, c.ns, name, pt, data, subresources...), &atomixv1.Proxy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*atomixv1.Proxy), err
+}
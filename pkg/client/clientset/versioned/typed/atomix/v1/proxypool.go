@@ -0,0 +1,216 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/atomix/controller/pkg/apis/atomix/v1"
+	scheme "github.com/atomix/controller/pkg/client/clientset/versioned/scheme"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ProxyPoolsGetter has a method to return a ProxyPoolInterface.
+// A group's client should implement this interface.
+type ProxyPoolsGetter interface {
+	ProxyPools(namespace string) ProxyPoolInterface
+}
+
+// ProxyPoolInterface has methods to work with ProxyPool resources.
+type ProxyPoolInterface interface {
+	Create(ctx context.Context, proxyPool *v1.ProxyPool, opts metav1.CreateOptions) (*v1.ProxyPool, error)
+	Update(ctx context.Context, proxyPool *v1.ProxyPool, opts metav1.UpdateOptions) (*v1.ProxyPool, error)
+	UpdateStatus(ctx context.Context, proxyPool *v1.ProxyPool, opts metav1.UpdateOptions) (*v1.ProxyPool, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.ProxyPool, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.ProxyPoolList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ProxyPool, err error)
+	GetScale(ctx context.Context, proxyPoolName string, options metav1.GetOptions) (*autoscalingv1.Scale, error)
+	UpdateScale(ctx context.Context, proxyPoolName string, scale *autoscalingv1.Scale, opts metav1.UpdateOptions) (*autoscalingv1.Scale, error)
+
+	ProxyPoolExpansion
+}
+
+// proxyPools implements ProxyPoolInterface
+type proxyPools struct {
+	client rest.Interface
+	ns     string
+}
+
+// newProxyPools returns a ProxyPools
+func newProxyPools(c *AtomixV1Client, namespace string) *proxyPools {
+	return &proxyPools{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the proxyPool, and returns the corresponding proxyPool object, and an error if there is any.
+func (c *proxyPools) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.ProxyPool, err error) {
+	result = &v1.ProxyPool{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("proxypools").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ProxyPools that match those selectors.
+func (c *proxyPools) List(ctx context.Context, opts metav1.ListOptions) (result *v1.ProxyPoolList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.ProxyPoolList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("proxypools").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested proxyPools.
+func (c *proxyPools) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("proxypools").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a proxyPool and creates it.  Returns the server's representation of the proxyPool, and an error, if there is any.
+func (c *proxyPools) Create(ctx context.Context, proxyPool *v1.ProxyPool, opts metav1.CreateOptions) (result *v1.ProxyPool, err error) {
+	result = &v1.ProxyPool{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("proxypools").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(proxyPool).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a proxyPool and updates it. Returns the server's representation of the proxyPool, and an error, if there is any.
+func (c *proxyPools) Update(ctx context.Context, proxyPool *v1.ProxyPool, opts metav1.UpdateOptions) (result *v1.ProxyPool, err error) {
+	result = &v1.ProxyPool{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("proxypools").
+		Name(proxyPool.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(proxyPool).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *proxyPools) UpdateStatus(ctx context.Context, proxyPool *v1.ProxyPool, opts metav1.UpdateOptions) (result *v1.ProxyPool, err error) {
+	result = &v1.ProxyPool{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("proxypools").
+		Name(proxyPool.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(proxyPool).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the proxyPool and deletes it. Returns an error if one occurs.
+func (c *proxyPools) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("proxypools").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *proxyPools) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("proxypools").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched proxyPool.
+func (c *proxyPools) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ProxyPool, err error) {
+	result = &v1.ProxyPool{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("proxypools").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// GetScale takes name of the proxyPool, and returns the corresponding autoscalingv1.Scale object, and an error if there is any.
+func (c *proxyPools) GetScale(ctx context.Context, proxyPoolName string, options metav1.GetOptions) (result *autoscalingv1.Scale, err error) {
+	result = &autoscalingv1.Scale{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("proxypools").
+		Name(proxyPoolName).
+		SubResource("scale").
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateScale takes the top resource name and the representation of a scale and updates it. Returns the server's representation of the scale, and an error, if there is any.
+func (c *proxyPools) UpdateScale(ctx context.Context, proxyPoolName string, scale *autoscalingv1.Scale, opts metav1.UpdateOptions) (result *autoscalingv1.Scale, err error) {
+	result = &autoscalingv1.Scale{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("proxypools").
+		Name(proxyPoolName).
+		SubResource("scale").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(scale).
+		Do(ctx).
+		Into(result)
+	return
+}
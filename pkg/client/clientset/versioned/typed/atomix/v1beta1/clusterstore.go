@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"context"
+	"time"
+
+	v1beta1 "github.com/atomix/controller/pkg/apis/atomix/v1beta1"
+	scheme "github.com/atomix/controller/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ClusterStoresGetter has a method to return a ClusterStoreInterface.
+// A group's client should implement this interface.
+type ClusterStoresGetter interface {
+	ClusterStores() ClusterStoreInterface
+}
+
+// ClusterStoreInterface has methods to work with ClusterStore resources.
+type ClusterStoreInterface interface {
+	Create(ctx context.Context, clusterStore *v1beta1.ClusterStore, opts v1.CreateOptions) (*v1beta1.ClusterStore, error)
+	Update(ctx context.Context, clusterStore *v1beta1.ClusterStore, opts v1.UpdateOptions) (*v1beta1.ClusterStore, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1beta1.ClusterStore, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1beta1.ClusterStoreList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.ClusterStore, err error)
+	ClusterStoreExpansion
+}
+
+// clusterStores implements ClusterStoreInterface
+type clusterStores struct {
+	client rest.Interface
+}
+
+// newClusterStores returns a ClusterStores
+func newClusterStores(c *AtomixV1beta1Client) *clusterStores {
+	return &clusterStores{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the clusterStore, and returns the corresponding clusterStore object, and an error if there is any.
+func (c *clusterStores) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.ClusterStore, err error) {
+	result = &v1beta1.ClusterStore{}
+	err = c.client.Get().
+		Resource("clusterstores").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ClusterStores that match those selectors.
+func (c *clusterStores) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.ClusterStoreList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1beta1.ClusterStoreList{}
+	err = c.client.Get().
+		Resource("clusterstores").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested clusterStores.
+func (c *clusterStores) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("clusterstores").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a clusterStore and creates it.  Returns the server's representation of the clusterStore, and an error, if there is any.
+func (c *clusterStores) Create(ctx context.Context, clusterStore *v1beta1.ClusterStore, opts v1.CreateOptions) (result *v1beta1.ClusterStore, err error) {
+	result = &v1beta1.ClusterStore{}
+	err = c.client.Post().
+		Resource("clusterstores").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterStore).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a clusterStore and updates it. Returns the server's representation of the clusterStore, and an error, if there is any.
+func (c *clusterStores) Update(ctx context.Context, clusterStore *v1beta1.ClusterStore, opts v1.UpdateOptions) (result *v1beta1.ClusterStore, err error) {
+	result = &v1beta1.ClusterStore{}
+	err = c.client.Put().
+		Resource("clusterstores").
+		Name(clusterStore.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterStore).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the clusterStore and deletes it. Returns an error if one occurs.
+func (c *clusterStores) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("clusterstores").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *clusterStores) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("clusterstores").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched clusterStore.
+func (c *clusterStores) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.ClusterStore, err error) {
+	result = &v1beta1.ClusterStore{}
+	err = c.client.Patch(pt).
+		Resource("clusterstores").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
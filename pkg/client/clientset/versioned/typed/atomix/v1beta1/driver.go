@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"context"
+	"time"
+
+	v1beta1 "github.com/atomix/controller/pkg/apis/atomix/v1beta1"
+	scheme "github.com/atomix/controller/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// DriversGetter has a method to return a DriverInterface.
+// A group's client should implement this interface.
+type DriversGetter interface {
+	Drivers() DriverInterface
+}
+
+// DriverInterface has methods to work with Driver resources.
+type DriverInterface interface {
+	Create(ctx context.Context, driver *v1beta1.Driver, opts v1.CreateOptions) (*v1beta1.Driver, error)
+	Update(ctx context.Context, driver *v1beta1.Driver, opts v1.UpdateOptions) (*v1beta1.Driver, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1beta1.Driver, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1beta1.DriverList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.Driver, err error)
+	DriverExpansion
+}
+
+// drivers implements DriverInterface
+type drivers struct {
+	client rest.Interface
+}
+
+// newDrivers returns a Drivers
+func newDrivers(c *AtomixV1beta1Client) *drivers {
+	return &drivers{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the driver, and returns the corresponding driver object, and an error if there is any.
+func (c *drivers) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.Driver, err error) {
+	result = &v1beta1.Driver{}
+	err = c.client.Get().
+		Resource("drivers").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of Drivers that match those selectors.
+func (c *drivers) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.DriverList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1beta1.DriverList{}
+	err = c.client.Get().
+		Resource("drivers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested drivers.
+func (c *drivers) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("drivers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a driver and creates it.  Returns the server's representation of the driver, and an error, if there is any.
+func (c *drivers) Create(ctx context.Context, driver *v1beta1.Driver, opts v1.CreateOptions) (result *v1beta1.Driver, err error) {
+	result = &v1beta1.Driver{}
+	err = c.client.Post().
+		Resource("drivers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(driver).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a driver and updates it. Returns the server's representation of the driver, and an error, if there is any.
+func (c *drivers) Update(ctx context.Context, driver *v1beta1.Driver, opts v1.UpdateOptions) (result *v1beta1.Driver, err error) {
+	result = &v1beta1.Driver{}
+	err = c.client.Put().
+		Resource("drivers").
+		Name(driver.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(driver).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the driver and deletes it. Returns an error if one occurs.
+func (c *drivers) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("drivers").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *drivers) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("drivers").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched driver.
+func (c *drivers) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.Driver, err error) {
+	result = &v1beta1.Driver{}
+	err = c.client.Patch(pt).
+		Resource("drivers").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
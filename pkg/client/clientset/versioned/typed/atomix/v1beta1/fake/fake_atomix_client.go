@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1beta1 "github.com/atomix/controller/pkg/client/clientset/versioned/typed/atomix/v1beta1"
+	rest "k8s.io/client-go/rest"
+	testing "k8s.io/client-go/testing"
+)
+
+type FakeAtomixV1beta1 struct {
+	*testing.Fake
+}
+
+func (c *FakeAtomixV1beta1) ClusterProfiles() v1beta1.ClusterProfileInterface {
+	return &FakeClusterProfiles{c}
+}
+
+func (c *FakeAtomixV1beta1) ClusterStores() v1beta1.ClusterStoreInterface {
+	return &FakeClusterStores{c}
+}
+
+func (c *FakeAtomixV1beta1) Drivers() v1beta1.DriverInterface {
+	return &FakeDrivers{c}
+}
+
+func (c *FakeAtomixV1beta1) Profiles(namespace string) v1beta1.ProfileInterface {
+	return &FakeProfiles{c, namespace}
+}
+
+func (c *FakeAtomixV1beta1) Proxies(namespace string) v1beta1.ProxyInterface {
+	return &FakeProxies{c, namespace}
+}
+
+func (c *FakeAtomixV1beta1) ProxyPools(namespace string) v1beta1.ProxyPoolInterface {
+	return &FakeProxyPools{c, namespace}
+}
+
+func (c *FakeAtomixV1beta1) Stores(namespace string) v1beta1.StoreInterface {
+	return &FakeStores{c, namespace}
+}
+
+func (c *FakeAtomixV1beta1) StoreGrants(namespace string) v1beta1.StoreGrantInterface {
+	return &FakeStoreGrants{c, namespace}
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *FakeAtomixV1beta1) RESTClient() rest.Interface {
+	var ret *rest.RESTClient
+	return ret
+}
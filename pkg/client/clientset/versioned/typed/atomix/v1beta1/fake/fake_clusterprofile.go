@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1beta1 "github.com/atomix/controller/pkg/apis/atomix/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeClusterProfiles implements ClusterProfileInterface
+type FakeClusterProfiles struct {
+	Fake *FakeAtomixV1beta1
+}
+
+var clusterprofilesResource = schema.GroupVersionResource{Group: "atomix.io", Version: "v1beta1", Resource: "clusterprofiles"}
+
+var clusterprofilesKind = schema.GroupVersionKind{Group: "atomix.io", Version: "v1beta1", Kind: "ClusterProfile"}
+
+// Get takes name of the clusterProfile, and returns the corresponding clusterProfile object, and an error if there is any.
+func (c *FakeClusterProfiles) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.ClusterProfile, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(clusterprofilesResource, name), &v1beta1.ClusterProfile{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ClusterProfile), err
+}
+
+// List takes label and field selectors, and returns the list of ClusterProfiles that match those selectors.
+func (c *FakeClusterProfiles) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.ClusterProfileList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(clusterprofilesResource, clusterprofilesKind, opts), &v1beta1.ClusterProfileList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1beta1.ClusterProfileList{ListMeta: obj.(*v1beta1.ClusterProfileList).ListMeta}
+	for _, item := range obj.(*v1beta1.ClusterProfileList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested clusterProfiles.
+func (c *FakeClusterProfiles) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(clusterprofilesResource, opts))
+}
+
+// Create takes the representation of a clusterProfile and creates it.  Returns the server's representation of the clusterProfile, and an error, if there is any.
+func (c *FakeClusterProfiles) Create(ctx context.Context, clusterProfile *v1beta1.ClusterProfile, opts v1.CreateOptions) (result *v1beta1.ClusterProfile, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(clusterprofilesResource, clusterProfile), &v1beta1.ClusterProfile{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ClusterProfile), err
+}
+
+// Update takes the representation of a clusterProfile and updates it. Returns the server's representation of the clusterProfile, and an error, if there is any.
+func (c *FakeClusterProfiles) Update(ctx context.Context, clusterProfile *v1beta1.ClusterProfile, opts v1.UpdateOptions) (result *v1beta1.ClusterProfile, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(clusterprofilesResource, clusterProfile), &v1beta1.ClusterProfile{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ClusterProfile), err
+}
+
+// Delete takes name of the clusterProfile and deletes it. Returns an error if one occurs.
+func (c *FakeClusterProfiles) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(clusterprofilesResource, name, opts), &v1beta1.ClusterProfile{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeClusterProfiles) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(clusterprofilesResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1beta1.ClusterProfileList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched clusterProfile.
+func (c *FakeClusterProfiles) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.ClusterProfile, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clusterprofilesResource, name, pt, data, subresources...), &v1beta1.ClusterProfile{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ClusterProfile), err
+}
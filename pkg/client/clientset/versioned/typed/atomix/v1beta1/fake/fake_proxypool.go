@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1beta1 "github.com/atomix/controller/pkg/apis/atomix/v1beta1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeProxyPools implements ProxyPoolInterface
+type FakeProxyPools struct {
+	Fake *FakeAtomixV1beta1
+	ns   string
+}
+
+var proxypoolsResource = schema.GroupVersionResource{Group: "atomix.io", Version: "v1beta1", Resource: "proxypools"}
+
+var proxypoolsKind = schema.GroupVersionKind{Group: "atomix.io", Version: "v1beta1", Kind: "ProxyPool"}
+
+// Get takes name of the proxyPool, and returns the corresponding proxyPool object, and an error if there is any.
+func (c *FakeProxyPools) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.ProxyPool, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(proxypoolsResource, c.ns, name), &v1beta1.ProxyPool{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ProxyPool), err
+}
+
+// List takes label and field selectors, and returns the list of ProxyPools that match those selectors.
+func (c *FakeProxyPools) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.ProxyPoolList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(proxypoolsResource, proxypoolsKind, c.ns, opts), &v1beta1.ProxyPoolList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1beta1.ProxyPoolList{ListMeta: obj.(*v1beta1.ProxyPoolList).ListMeta}
+	for _, item := range obj.(*v1beta1.ProxyPoolList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested proxyPools.
+func (c *FakeProxyPools) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(proxypoolsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a proxyPool and creates it.  Returns the server's representation of the proxyPool, and an error, if there is any.
+func (c *FakeProxyPools) Create(ctx context.Context, proxyPool *v1beta1.ProxyPool, opts v1.CreateOptions) (result *v1beta1.ProxyPool, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(proxypoolsResource, c.ns, proxyPool), &v1beta1.ProxyPool{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ProxyPool), err
+}
+
+// Update takes the representation of a proxyPool and updates it. Returns the server's representation of the proxyPool, and an error, if there is any.
+func (c *FakeProxyPools) Update(ctx context.Context, proxyPool *v1beta1.ProxyPool, opts v1.UpdateOptions) (result *v1beta1.ProxyPool, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(proxypoolsResource, c.ns, proxyPool), &v1beta1.ProxyPool{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ProxyPool), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeProxyPools) UpdateStatus(ctx context.Context, proxyPool *v1beta1.ProxyPool, opts v1.UpdateOptions) (*v1beta1.ProxyPool, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(proxypoolsResource, "status", c.ns, proxyPool), &v1beta1.ProxyPool{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ProxyPool), err
+}
+
+// Delete takes name of the proxyPool and deletes it. Returns an error if one occurs.
+func (c *FakeProxyPools) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(proxypoolsResource, c.ns, name, opts), &v1beta1.ProxyPool{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeProxyPools) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(proxypoolsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1beta1.ProxyPoolList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched proxyPool.
+func (c *FakeProxyPools) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.ProxyPool, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(proxypoolsResource, c.ns, name, pt, data, subresources...), &v1beta1.ProxyPool{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ProxyPool), err
+}
+
+// GetScale takes name of the proxyPool, and returns the corresponding scale object, and an error if there is any.
+func (c *FakeProxyPools) GetScale(ctx context.Context, proxyPoolName string, options v1.GetOptions) (result *autoscalingv1.Scale, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetSubresourceAction(proxypoolsResource, c.ns, "scale", proxyPoolName), &autoscalingv1.Scale{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*autoscalingv1.Scale), err
+}
+
+// UpdateScale takes the representation of a scale and updates it. Returns the server's representation of the scale, and an error, if there is any.
+func (c *FakeProxyPools) UpdateScale(ctx context.Context, proxyPoolName string, scale *autoscalingv1.Scale, opts v1.UpdateOptions) (result *autoscalingv1.Scale, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(proxypoolsResource, "scale", c.ns, scale), &autoscalingv1.Scale{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*autoscalingv1.Scale), err
+}
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1beta1 "github.com/atomix/controller/pkg/apis/atomix/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeStoreGrants implements StoreGrantInterface
+type FakeStoreGrants struct {
+	Fake *FakeAtomixV1beta1
+	ns   string
+}
+
+var storegrantsResource = schema.GroupVersionResource{Group: "atomix.io", Version: "v1beta1", Resource: "storegrants"}
+
+var storegrantsKind = schema.GroupVersionKind{Group: "atomix.io", Version: "v1beta1", Kind: "StoreGrant"}
+
+// Get takes name of the storeGrant, and returns the corresponding storeGrant object, and an error if there is any.
+func (c *FakeStoreGrants) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.StoreGrant, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(storegrantsResource, c.ns, name), &v1beta1.StoreGrant{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.StoreGrant), err
+}
+
+// List takes label and field selectors, and returns the list of StoreGrants that match those selectors.
+func (c *FakeStoreGrants) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.StoreGrantList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(storegrantsResource, storegrantsKind, c.ns, opts), &v1beta1.StoreGrantList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1beta1.StoreGrantList{ListMeta: obj.(*v1beta1.StoreGrantList).ListMeta}
+	for _, item := range obj.(*v1beta1.StoreGrantList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested storeGrants.
+func (c *FakeStoreGrants) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(storegrantsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a storeGrant and creates it.  Returns the server's representation of the storeGrant, and an error, if there is any.
+func (c *FakeStoreGrants) Create(ctx context.Context, storeGrant *v1beta1.StoreGrant, opts v1.CreateOptions) (result *v1beta1.StoreGrant, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(storegrantsResource, c.ns, storeGrant), &v1beta1.StoreGrant{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.StoreGrant), err
+}
+
+// Update takes the representation of a storeGrant and updates it. Returns the server's representation of the storeGrant, and an error, if there is any.
+func (c *FakeStoreGrants) Update(ctx context.Context, storeGrant *v1beta1.StoreGrant, opts v1.UpdateOptions) (result *v1beta1.StoreGrant, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(storegrantsResource, c.ns, storeGrant), &v1beta1.StoreGrant{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.StoreGrant), err
+}
+
+// Delete takes name of the storeGrant and deletes it. Returns an error if one occurs.
+func (c *FakeStoreGrants) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(storegrantsResource, c.ns, name, opts), &v1beta1.StoreGrant{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeStoreGrants) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(storegrantsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1beta1.StoreGrantList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched storeGrant.
+func (c *FakeStoreGrants) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.StoreGrant, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(storegrantsResource, c.ns, name, pt, data, subresources...), &v1beta1.StoreGrant{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.StoreGrant), err
+}
@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1beta1
+
+type ClusterProfileExpansion interface{}
+
+type ClusterStoreExpansion interface{}
+
+type DriverExpansion interface{}
+
+type ProfileExpansion interface{}
+
+type ProxyExpansion interface{}
+
+type ProxyPoolExpansion interface{}
+
+type StoreExpansion interface{}
+
+type StoreGrantExpansion interface{}
@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"context"
+	"time"
+
+	v1beta1 "github.com/atomix/controller/pkg/apis/atomix/v1beta1"
+	scheme "github.com/atomix/controller/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// StoreGrantsGetter has a method to return a StoreGrantInterface.
+// A group's client should implement this interface.
+type StoreGrantsGetter interface {
+	StoreGrants(namespace string) StoreGrantInterface
+}
+
+// StoreGrantInterface has methods to work with StoreGrant resources.
+type StoreGrantInterface interface {
+	Create(ctx context.Context, storeGrant *v1beta1.StoreGrant, opts v1.CreateOptions) (*v1beta1.StoreGrant, error)
+	Update(ctx context.Context, storeGrant *v1beta1.StoreGrant, opts v1.UpdateOptions) (*v1beta1.StoreGrant, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1beta1.StoreGrant, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1beta1.StoreGrantList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.StoreGrant, err error)
+	StoreGrantExpansion
+}
+
+// storeGrants implements StoreGrantInterface
+type storeGrants struct {
+	client rest.Interface
+	ns     string
+}
+
+// newStoreGrants returns a StoreGrants
+func newStoreGrants(c *AtomixV1beta1Client, namespace string) *storeGrants {
+	return &storeGrants{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the storeGrant, and returns the corresponding storeGrant object, and an error if there is any.
+func (c *storeGrants) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.StoreGrant, err error) {
+	result = &v1beta1.StoreGrant{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("storegrants").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of StoreGrants that match those selectors.
+func (c *storeGrants) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.StoreGrantList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1beta1.StoreGrantList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("storegrants").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested storeGrants.
+func (c *storeGrants) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("storegrants").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a storeGrant and creates it.  Returns the server's representation of the storeGrant, and an error, if there is any.
+func (c *storeGrants) Create(ctx context.Context, storeGrant *v1beta1.StoreGrant, opts v1.CreateOptions) (result *v1beta1.StoreGrant, err error) {
+	result = &v1beta1.StoreGrant{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("storegrants").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(storeGrant).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a storeGrant and updates it. Returns the server's representation of the storeGrant, and an error, if there is any.
+func (c *storeGrants) Update(ctx context.Context, storeGrant *v1beta1.StoreGrant, opts v1.UpdateOptions) (result *v1beta1.StoreGrant, err error) {
+	result = &v1beta1.StoreGrant{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("storegrants").
+		Name(storeGrant.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(storeGrant).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the storeGrant and deletes it. Returns an error if one occurs.
+func (c *storeGrants) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("storegrants").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *storeGrants) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("storegrants").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched storeGrant.
+func (c *storeGrants) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.StoreGrant, err error) {
+	result = &v1beta1.StoreGrant{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("storegrants").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	internalinterfaces "github.com/atomix/controller/pkg/client/informers/externalversions/internalinterfaces"
+)
+
+// Interface provides access to all the informers in this group version.
+type Interface interface {
+	// ClusterProfiles returns a ClusterProfileInformer.
+	ClusterProfiles() ClusterProfileInformer
+	// ClusterStores returns a ClusterStoreInformer.
+	ClusterStores() ClusterStoreInformer
+	// Drivers returns a DriverInformer.
+	Drivers() DriverInformer
+	// Profiles returns a ProfileInformer.
+	Profiles() ProfileInformer
+	// Proxies returns a ProxyInformer.
+	Proxies() ProxyInformer
+	// ProxyPools returns a ProxyPoolInformer.
+	ProxyPools() ProxyPoolInformer
+	// Stores returns a StoreInformer.
+	Stores() StoreInformer
+	// StoreGrants returns a StoreGrantInformer.
+	StoreGrants() StoreGrantInformer
+}
+
+type version struct {
+	factory          internalinterfaces.SharedInformerFactory
+	namespace        string
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// New returns a new Interface.
+func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakListOptions internalinterfaces.TweakListOptionsFunc) Interface {
+	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
+}
+
+// ClusterProfiles returns a ClusterProfileInformer.
+func (v *version) ClusterProfiles() ClusterProfileInformer {
+	return &clusterProfileInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// ClusterStores returns a ClusterStoreInformer.
+func (v *version) ClusterStores() ClusterStoreInformer {
+	return &clusterStoreInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// Drivers returns a DriverInformer.
+func (v *version) Drivers() DriverInformer {
+	return &driverInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// Profiles returns a ProfileInformer.
+func (v *version) Profiles() ProfileInformer {
+	return &profileInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// Proxies returns a ProxyInformer.
+func (v *version) Proxies() ProxyInformer {
+	return &proxyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ProxyPools returns a ProxyPoolInformer.
+func (v *version) ProxyPools() ProxyPoolInformer {
+	return &proxyPoolInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// Stores returns a StoreInformer.
+func (v *version) Stores() StoreInformer {
+	return &storeInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// StoreGrants returns a StoreGrantInformer.
+func (v *version) StoreGrants() StoreGrantInformer {
+	return &storeGrantInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
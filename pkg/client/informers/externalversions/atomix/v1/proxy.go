@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	time "time"
+
+	atomixv1 "github.com/atomix/controller/pkg/apis/atomix/v1"
+	versioned "github.com/atomix/controller/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/atomix/controller/pkg/client/informers/externalversions/internalinterfaces"
+	v1 "github.com/atomix/controller/pkg/client/listers/atomix/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ProxyInformer provides access to a shared informer and lister for
+// Proxies.
+type ProxyInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1.ProxyLister
+}
+
+type proxyInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewProxyInformer constructs a new informer for Proxy type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewProxyInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredProxyInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredProxyInformer constructs a new informer for Proxy type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredProxyInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AtomixV1().Proxies(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AtomixV1().Proxies(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&atomixv1.Proxy{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *proxyInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredProxyInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *proxyInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&atomixv1.Proxy{}, f.defaultInformer)
+}
+
+func (f *proxyInformer) Lister() v1.ProxyLister {
+	return v1.NewProxyLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"context"
+	time "time"
+
+	atomixv1beta1 "github.com/atomix/controller/pkg/apis/atomix/v1beta1"
+	versioned "github.com/atomix/controller/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/atomix/controller/pkg/client/informers/externalversions/internalinterfaces"
+	v1beta1 "github.com/atomix/controller/pkg/client/listers/atomix/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ClusterProfileInformer provides access to a shared informer and lister for
+// ClusterProfiles.
+type ClusterProfileInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1beta1.ClusterProfileLister
+}
+
+type clusterProfileInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewClusterProfileInformer constructs a new informer for ClusterProfile type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewClusterProfileInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredClusterProfileInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredClusterProfileInformer constructs a new informer for ClusterProfile type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredClusterProfileInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AtomixV1beta1().ClusterProfiles().List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AtomixV1beta1().ClusterProfiles().Watch(context.TODO(), options)
+			},
+		},
+		&atomixv1beta1.ClusterProfile{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *clusterProfileInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredClusterProfileInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *clusterProfileInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&atomixv1beta1.ClusterProfile{}, f.defaultInformer)
+}
+
+func (f *clusterProfileInformer) Lister() v1beta1.ClusterProfileLister {
+	return v1beta1.NewClusterProfileLister(f.Informer().GetIndexer())
+}
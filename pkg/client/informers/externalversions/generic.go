@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package externalversions
+
+import (
+	"fmt"
+
+	v1 "github.com/atomix/controller/pkg/apis/atomix/v1"
+	v1beta1 "github.com/atomix/controller/pkg/apis/atomix/v1beta1"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// GenericInformer is type of SharedIndexInformer which will locate and delegate to other
+// sharedInformers based on type
+type GenericInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() cache.GenericLister
+}
+
+type genericInformer struct {
+	informer cache.SharedIndexInformer
+	resource schema.GroupResource
+}
+
+// Informer returns the SharedIndexInformer.
+func (f *genericInformer) Informer() cache.SharedIndexInformer {
+	return f.informer
+}
+
+// Lister returns the GenericLister.
+func (f *genericInformer) Lister() cache.GenericLister {
+	return cache.NewGenericLister(f.Informer().GetIndexer(), f.resource)
+}
+
+// ForResource gives generic access to a shared informer of the matching type
+// TODO extend this to unknown resources with a client pool
+func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
+	switch resource {
+	// Group=atomix.io, Version=v1
+	case v1.SchemeGroupVersion.WithResource("clusterprofiles"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Atomix().V1().ClusterProfiles().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("clusterstores"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Atomix().V1().ClusterStores().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("drivers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Atomix().V1().Drivers().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("profiles"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Atomix().V1().Profiles().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("proxies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Atomix().V1().Proxies().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("proxypools"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Atomix().V1().ProxyPools().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("stores"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Atomix().V1().Stores().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("storegrants"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Atomix().V1().StoreGrants().Informer()}, nil
+
+		// Group=atomix.io, Version=v1beta1
+	case v1beta1.SchemeGroupVersion.WithResource("clusterprofiles"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Atomix().V1beta1().ClusterProfiles().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("clusterstores"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Atomix().V1beta1().ClusterStores().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("drivers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Atomix().V1beta1().Drivers().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("profiles"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Atomix().V1beta1().Profiles().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("proxies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Atomix().V1beta1().Proxies().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("proxypools"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Atomix().V1beta1().ProxyPools().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("stores"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Atomix().V1beta1().Stores().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("storegrants"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Atomix().V1beta1().StoreGrants().Informer()}, nil
+
+	}
+
+	return nil, fmt.Errorf("no informer found for %v", resource)
+}
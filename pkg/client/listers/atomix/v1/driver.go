@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/atomix/controller/pkg/apis/atomix/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// DriverLister helps list Drivers.
+// All objects returned here must be treated as read-only.
+type DriverLister interface {
+	// List lists all Drivers in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.Driver, err error)
+	// Get retrieves the Driver from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.Driver, error)
+	DriverListerExpansion
+}
+
+// driverLister implements the DriverLister interface.
+type driverLister struct {
+	indexer cache.Indexer
+}
+
+// NewDriverLister returns a new DriverLister.
+func NewDriverLister(indexer cache.Indexer) DriverLister {
+	return &driverLister{indexer: indexer}
+}
+
+// List lists all Drivers in the indexer.
+func (s *driverLister) List(selector labels.Selector) (ret []*v1.Driver, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.Driver))
+	})
+	return ret, err
+}
+
+// Get retrieves the Driver from the index for a given name.
+func (s *driverLister) Get(name string) (*v1.Driver, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("driver"), name)
+	}
+	return obj.(*v1.Driver), nil
+}
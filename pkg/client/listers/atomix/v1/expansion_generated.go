@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+// ClusterProfileListerExpansion allows custom methods to be added to
+// ClusterProfileLister.
+type ClusterProfileListerExpansion interface{}
+
+// ClusterStoreListerExpansion allows custom methods to be added to
+// ClusterStoreLister.
+type ClusterStoreListerExpansion interface{}
+
+// DriverListerExpansion allows custom methods to be added to
+// DriverLister.
+type DriverListerExpansion interface{}
+
+// ProfileListerExpansion allows custom methods to be added to
+// ProfileLister.
+type ProfileListerExpansion interface{}
+
+// ProfileNamespaceListerExpansion allows custom methods to be added to
+// ProfileNamespaceLister.
+type ProfileNamespaceListerExpansion interface{}
+
+// ProxyListerExpansion allows custom methods to be added to
+// ProxyLister.
+type ProxyListerExpansion interface{}
+
+// ProxyNamespaceListerExpansion allows custom methods to be added to
+// ProxyNamespaceLister.
+type ProxyNamespaceListerExpansion interface{}
+
+// ProxyPoolListerExpansion allows custom methods to be added to
+// ProxyPoolLister.
+type ProxyPoolListerExpansion interface{}
+
+// ProxyPoolNamespaceListerExpansion allows custom methods to be added to
+// ProxyPoolNamespaceLister.
+type ProxyPoolNamespaceListerExpansion interface{}
+
+// StoreListerExpansion allows custom methods to be added to
+// StoreLister.
+type StoreListerExpansion interface{}
+
+// StoreNamespaceListerExpansion allows custom methods to be added to
+// StoreNamespaceLister.
+type StoreNamespaceListerExpansion interface{}
+
+// StoreGrantListerExpansion allows custom methods to be added to
+// StoreGrantLister.
+type StoreGrantListerExpansion interface{}
+
+// StoreGrantNamespaceListerExpansion allows custom methods to be added to
+// StoreGrantNamespaceLister.
+type StoreGrantNamespaceListerExpansion interface{}
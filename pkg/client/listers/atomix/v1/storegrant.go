@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/atomix/controller/pkg/apis/atomix/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// StoreGrantLister helps list StoreGrants.
+// All objects returned here must be treated as read-only.
+type StoreGrantLister interface {
+	// List lists all StoreGrants in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.StoreGrant, err error)
+	// StoreGrants returns an object that can list and get StoreGrants.
+	StoreGrants(namespace string) StoreGrantNamespaceLister
+	StoreGrantListerExpansion
+}
+
+// storeGrantLister implements the StoreGrantLister interface.
+type storeGrantLister struct {
+	indexer cache.Indexer
+}
+
+// NewStoreGrantLister returns a new StoreGrantLister.
+func NewStoreGrantLister(indexer cache.Indexer) StoreGrantLister {
+	return &storeGrantLister{indexer: indexer}
+}
+
+// List lists all StoreGrants in the indexer.
+func (s *storeGrantLister) List(selector labels.Selector) (ret []*v1.StoreGrant, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.StoreGrant))
+	})
+	return ret, err
+}
+
+// StoreGrants returns an object that can list and get StoreGrants.
+func (s *storeGrantLister) StoreGrants(namespace string) StoreGrantNamespaceLister {
+	return storeGrantNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// StoreGrantNamespaceLister helps list and get StoreGrants.
+// All objects returned here must be treated as read-only.
+type StoreGrantNamespaceLister interface {
+	// List lists all StoreGrants in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.StoreGrant, err error)
+	// Get retrieves the StoreGrant from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.StoreGrant, error)
+	StoreGrantNamespaceListerExpansion
+}
+
+// storeGrantNamespaceLister implements the StoreGrantNamespaceLister
+// interface.
+type storeGrantNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all StoreGrants in the indexer for a given namespace.
+func (s storeGrantNamespaceLister) List(selector labels.Selector) (ret []*v1.StoreGrant, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.StoreGrant))
+	})
+	return ret, err
+}
+
+// Get retrieves the StoreGrant from the indexer for a given namespace and name.
+func (s storeGrantNamespaceLister) Get(name string) (*v1.StoreGrant, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("storegrant"), name)
+	}
+	return obj.(*v1.StoreGrant), nil
+}
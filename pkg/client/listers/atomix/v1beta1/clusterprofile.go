@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "github.com/atomix/controller/pkg/apis/atomix/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ClusterProfileLister helps list ClusterProfiles.
+// All objects returned here must be treated as read-only.
+type ClusterProfileLister interface {
+	// List lists all ClusterProfiles in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.ClusterProfile, err error)
+	// Get retrieves the ClusterProfile from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1beta1.ClusterProfile, error)
+	ClusterProfileListerExpansion
+}
+
+// clusterProfileLister implements the ClusterProfileLister interface.
+type clusterProfileLister struct {
+	indexer cache.Indexer
+}
+
+// NewClusterProfileLister returns a new ClusterProfileLister.
+func NewClusterProfileLister(indexer cache.Indexer) ClusterProfileLister {
+	return &clusterProfileLister{indexer: indexer}
+}
+
+// List lists all ClusterProfiles in the indexer.
+func (s *clusterProfileLister) List(selector labels.Selector) (ret []*v1beta1.ClusterProfile, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.ClusterProfile))
+	})
+	return ret, err
+}
+
+// Get retrieves the ClusterProfile from the index for a given name.
+func (s *clusterProfileLister) Get(name string) (*v1beta1.ClusterProfile, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1beta1.Resource("clusterprofile"), name)
+	}
+	return obj.(*v1beta1.ClusterProfile), nil
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "github.com/atomix/controller/pkg/apis/atomix/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ClusterStoreLister helps list ClusterStores.
+// All objects returned here must be treated as read-only.
+type ClusterStoreLister interface {
+	// List lists all ClusterStores in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.ClusterStore, err error)
+	// Get retrieves the ClusterStore from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1beta1.ClusterStore, error)
+	ClusterStoreListerExpansion
+}
+
+// clusterStoreLister implements the ClusterStoreLister interface.
+type clusterStoreLister struct {
+	indexer cache.Indexer
+}
+
+// NewClusterStoreLister returns a new ClusterStoreLister.
+func NewClusterStoreLister(indexer cache.Indexer) ClusterStoreLister {
+	return &clusterStoreLister{indexer: indexer}
+}
+
+// List lists all ClusterStores in the indexer.
+func (s *clusterStoreLister) List(selector labels.Selector) (ret []*v1beta1.ClusterStore, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.ClusterStore))
+	})
+	return ret, err
+}
+
+// Get retrieves the ClusterStore from the index for a given name.
+func (s *clusterStoreLister) Get(name string) (*v1beta1.ClusterStore, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1beta1.Resource("clusterstore"), name)
+	}
+	return obj.(*v1beta1.ClusterStore), nil
+}
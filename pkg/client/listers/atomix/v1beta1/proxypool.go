@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "github.com/atomix/controller/pkg/apis/atomix/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ProxyPoolLister helps list ProxyPools.
+// All objects returned here must be treated as read-only.
+type ProxyPoolLister interface {
+	// List lists all ProxyPools in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.ProxyPool, err error)
+	// ProxyPools returns an object that can list and get ProxyPools.
+	ProxyPools(namespace string) ProxyPoolNamespaceLister
+	ProxyPoolListerExpansion
+}
+
+// proxyPoolLister implements the ProxyPoolLister interface.
+type proxyPoolLister struct {
+	indexer cache.Indexer
+}
+
+// NewProxyPoolLister returns a new ProxyPoolLister.
+func NewProxyPoolLister(indexer cache.Indexer) ProxyPoolLister {
+	return &proxyPoolLister{indexer: indexer}
+}
+
+// List lists all ProxyPools in the indexer.
+func (s *proxyPoolLister) List(selector labels.Selector) (ret []*v1beta1.ProxyPool, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.ProxyPool))
+	})
+	return ret, err
+}
+
+// ProxyPools returns an object that can list and get ProxyPools.
+func (s *proxyPoolLister) ProxyPools(namespace string) ProxyPoolNamespaceLister {
+	return proxyPoolNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// ProxyPoolNamespaceLister helps list and get ProxyPools.
+// All objects returned here must be treated as read-only.
+type ProxyPoolNamespaceLister interface {
+	// List lists all ProxyPools in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.ProxyPool, err error)
+	// Get retrieves the ProxyPool from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1beta1.ProxyPool, error)
+	ProxyPoolNamespaceListerExpansion
+}
+
+// proxyPoolNamespaceLister implements the ProxyPoolNamespaceLister
+// interface.
+type proxyPoolNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all ProxyPools in the indexer for a given namespace.
+func (s proxyPoolNamespaceLister) List(selector labels.Selector) (ret []*v1beta1.ProxyPool, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.ProxyPool))
+	})
+	return ret, err
+}
+
+// Get retrieves the ProxyPool from the indexer for a given namespace and name.
+func (s proxyPoolNamespaceLister) Get(name string) (*v1beta1.ProxyPool, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1beta1.Resource("proxypool"), name)
+	}
+	return obj.(*v1beta1.ProxyPool), nil
+}
@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"context"
+	atomixv1beta1 "github.com/atomix/controller/pkg/apis/atomix/v1beta1"
+	controllerconfig "github.com/atomix/controller/pkg/controller/config"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+func addClusterProfileController(mgr manager.Manager, controllerConfig *controllerconfig.Watcher) error {
+	controllerOptions := controllerConfig.Get().GetController("cluster-profile-controller")
+
+	// Create a new controller
+	c, err := controller.New("cluster-profile-controller", mgr, controller.Options{
+		Reconciler: &ClusterProfileReconciler{
+			client: mgr.GetClient(),
+			scheme: mgr.GetScheme(),
+			config: mgr.GetConfig(),
+
+			controllerConfig: controllerConfig,
+		},
+		MaxConcurrentReconciles: controllerOptions.MaxConcurrentReconciles,
+		RateLimiter:             newRateLimiter(controllerOptions.RateLimiter),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterProfiles
+	err = c.Watch(&source.Kind{Type: &atomixv1beta1.ClusterProfile{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to Proxies
+	err = c.Watch(&source.Kind{Type: &atomixv1beta1.Proxy{}}, handler.EnqueueRequestsFromMapFunc(func(object client.Object) []reconcile.Request {
+		proxy := object.(*atomixv1beta1.Proxy)
+		if proxy.Profile.Kind != clusterProfileKind {
+			return nil
+		}
+		return []reconcile.Request{
+			{
+				NamespacedName: types.NamespacedName{
+					Name: proxy.Profile.Name,
+				},
+			},
+		}
+	}))
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ConfigMap
+	err = c.Watch(&source.Kind{Type: &corev1.ConfigMap{}}, &handler.EnqueueRequestForOwner{
+		OwnerType: &atomixv1beta1.ClusterProfile{},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to NetworkPolicies
+	err = c.Watch(&source.Kind{Type: &networkingv1.NetworkPolicy{}}, &handler.EnqueueRequestForOwner{
+		OwnerType:    &atomixv1beta1.ClusterProfile{},
+		IsController: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Reconcile all ClusterProfiles when the configuration changes to enable or disable their NetworkPolicies
+	err = c.Watch(newConfigSource(controllerConfig, func(ctx context.Context) ([]reconcile.Request, error) {
+		clusterProfileList := &atomixv1beta1.ClusterProfileList{}
+		if err := mgr.GetClient().List(ctx, clusterProfileList); err != nil {
+			return nil, err
+		}
+		var requests []reconcile.Request
+		for _, clusterProfile := range clusterProfileList.Items {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: getNamespacedName(&clusterProfile),
+			})
+		}
+		return requests, nil
+	}), &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// ClusterProfileReconciler is a Reconciler for ClusterProfiles
+type ClusterProfileReconciler struct {
+	client           client.Client
+	scheme           *runtime.Scheme
+	config           *rest.Config
+	controllerConfig *controllerconfig.Watcher
+}
+
+// Reconcile reconciles ClusterProfile resources
+func (r *ClusterProfileReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log.Infof("Reconciling ClusterProfile '%s'", request.Name)
+	clusterProfile := &atomixv1beta1.ClusterProfile{}
+	err := r.client.Get(ctx, request.NamespacedName, clusterProfile)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		log.Error(err)
+		return reconcile.Result{}, err
+	}
+
+	// ClusterProfiles are consumed by Proxies in any namespace, so the proxy configuration
+	// must be created in each namespace in which the profile is referenced. The injector creates
+	// the configuration when a pod is admitted, so namespaces with pods but no Proxies are updated too.
+	proxyList := &atomixv1beta1.ProxyList{}
+	if err := r.client.List(ctx, proxyList); err != nil {
+		log.Error(err)
+		return reconcile.Result{}, err
+	}
+
+	namespaces := make(map[string]bool)
+	for _, proxy := range proxyList.Items {
+		if proxy.Profile.Kind == clusterProfileKind && proxy.Profile.Name == clusterProfile.Name {
+			namespaces[proxy.Namespace] = true
+		}
+	}
+	for _, request := range getProfilePodRequests(r.client, "", clusterProfileKind, clusterProfile.Name) {
+		namespaces[request.Namespace] = true
+	}
+
+	ref := atomixv1beta1.ProfileReference{
+		Kind: clusterProfileKind,
+		Name: clusterProfile.Name,
+	}
+	networkPolicyConfig := r.controllerConfig.Get().Proxy.NetworkPolicy
+	for namespace := range namespaces {
+		if err := reconcileProfileConfigMap(ctx, r.client, r.scheme, clusterProfile, namespace, ref, clusterProfile.Spec); err != nil {
+			return reconcile.Result{}, err
+		}
+		if err := reconcileProfileNetworkPolicy(ctx, r.client, r.scheme, clusterProfile, namespace, ref, networkPolicyConfig); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{}, nil
+}
@@ -5,28 +5,77 @@
 package v1beta1
 
 import (
+	"fmt"
+	atomixv1 "github.com/atomix/controller/pkg/apis/atomix/v1"
+	controllerconfig "github.com/atomix/controller/pkg/controller/config"
 	"github.com/atomix/runtime/pkg/logging"
+	"go.opentelemetry.io/otel"
+	"hash/fnv"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+	"strings"
 )
 
 var log = logging.GetLogger()
 
+var tracer = otel.Tracer("github.com/atomix/controller/pkg/controller/atomix/v1beta1")
+
 // AddControllers adds sidecar controllers to the given manager
-func AddControllers(mgr manager.Manager) error {
-	if err := addProxyController(mgr); err != nil {
+func AddControllers(mgr manager.Manager, controllerConfig *controllerconfig.Watcher) error {
+	if err := addConversionWebhook(mgr); err != nil {
+		return err
+	}
+	if err := addStoreWebhook(mgr); err != nil {
+		return err
+	}
+	if err := addProxyController(mgr, controllerConfig); err != nil {
+		return err
+	}
+	if err := addProfileController(mgr, controllerConfig); err != nil {
 		return err
 	}
-	if err := addProfileController(mgr); err != nil {
+	if err := addClusterProfileController(mgr, controllerConfig); err != nil {
 		return err
 	}
-	if err := addPodController(mgr); err != nil {
+	if err := addPodController(mgr, controllerConfig); err != nil {
 		return err
 	}
+	if err := addNodeProxyController(mgr, controllerConfig); err != nil {
+		return err
+	}
+	if err := addProxyPoolController(mgr, controllerConfig); err != nil {
+		return err
+	}
+	return nil
+}
+
+// addConversionWebhook adds a webhook converting resources between API versions
+func addConversionWebhook(mgr manager.Manager) error {
+	mgr.GetWebhookServer().Register(atomixv1.ConversionWebhookPath, &conversion.Webhook{})
 	return nil
 }
 
+// GetCacheSelectors returns the selectors limiting the objects cached by the manager
+// Only pods into which a proxy was injected are cached.
+func GetCacheSelectors() cache.SelectorsByObject {
+	return cache.SelectorsByObject{
+		&corev1.Pod{}: {
+			Label: labels.SelectorFromSet(labels.Set{proxyInjectedLabel: "true"}),
+		},
+	}
+}
+
+// newRateLimiter returns a controller rate limiter for the given configuration
+func newRateLimiter(config controllerconfig.RateLimiterConfig) workqueue.RateLimiter {
+	return workqueue.NewItemExponentialFailureRateLimiter(config.BaseDelay, config.MaxDelay)
+}
+
 func hasFinalizer(object client.Object, name string) bool {
 	for _, finalizer := range object.GetFinalizers() {
 		if finalizer == name {
@@ -36,6 +85,15 @@ func hasFinalizer(object client.Object, name string) bool {
 	return false
 }
 
+func isOwnedBy(object client.Object, owner client.Object) bool {
+	for _, ref := range object.GetOwnerReferences() {
+		if ref.UID == owner.GetUID() {
+			return true
+		}
+	}
+	return false
+}
+
 func addFinalizer(object client.Object, name string) {
 	object.SetFinalizers(append(object.GetFinalizers(), name))
 }
@@ -56,3 +114,15 @@ func getNamespacedName(object client.Object) types.NamespacedName {
 		Name:      object.GetName(),
 	}
 }
+
+// truncateName returns the given name if it fits in maxLength characters, otherwise a prefix of the
+// name suffixed with a hash of the full name, so distinct names remain distinct after truncation
+func truncateName(name string, maxLength int) string {
+	if len(name) <= maxLength {
+		return name
+	}
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(name))
+	suffix := fmt.Sprintf("%08x", hash.Sum32())
+	return fmt.Sprintf("%s-%s", strings.TrimRight(name[:maxLength-len(suffix)-1], "-._"), suffix)
+}
@@ -0,0 +1,227 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	atomixv1beta1 "github.com/atomix/controller/pkg/apis/atomix/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	structuraldefaulting "k8s.io/apiextensions-apiserver/pkg/apiserver/schema/defaulting"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strings"
+)
+
+// getDriver gets the registered Driver with the given name
+func getDriver(ctx context.Context, c client.Client, name string) (*atomixv1beta1.Driver, error) {
+	driver := &atomixv1beta1.Driver{}
+	if err := c.Get(ctx, types.NamespacedName{Name: name}, driver); err != nil {
+		return nil, err
+	}
+	return driver, nil
+}
+
+// getDriverVersion returns the given version of the driver if it's supported
+func getDriverVersion(driver *atomixv1beta1.Driver, name string) (*atomixv1beta1.DriverVersion, bool) {
+	for _, version := range driver.Spec.Versions {
+		if version.Name == name {
+			return &version, true
+		}
+	}
+	return nil, false
+}
+
+// isRuntimeVersionCompatible returns whether the driver version is compatible with the given runtime version.
+// Runtime versions match either exactly or by prefix, e.g. 'v0.7' matches runtime version 'v0.7.2'.
+// Driver versions that do not declare runtime versions are compatible with all runtimes.
+func isRuntimeVersionCompatible(version *atomixv1beta1.DriverVersion, runtimeVersion string) bool {
+	if len(version.RuntimeVersions) == 0 {
+		return true
+	}
+	for _, compatibleVersion := range version.RuntimeVersions {
+		if compatibleVersion == "*" || compatibleVersion == runtimeVersion ||
+			strings.HasPrefix(runtimeVersion, compatibleVersion+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// driverPlugin is a plugin to be delivered for a driver
+type driverPlugin struct {
+	driver atomixv1beta1.DriverReference
+	plugin atomixv1beta1.DriverPlugin
+}
+
+// getDriverPlugins returns the plugins for the drivers of the stores bound by the given profile.
+// Stores and drivers that cannot be found are skipped, as are driver versions that do not declare a plugin.
+func getDriverPlugins(ctx context.Context, c client.Client, namespace string, profile *atomixv1beta1.ProfileSpec) ([]driverPlugin, error) {
+	var plugins []driverPlugin
+	found := make(map[atomixv1beta1.DriverReference]bool)
+	for _, binding := range profile.Bindings {
+		_, store, err := getStore(ctx, c, binding.Store.Kind, getStoreID(namespace, binding.Store))
+		if err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return nil, err
+			}
+			continue
+		}
+
+		if found[store.Driver] {
+			continue
+		}
+		found[store.Driver] = true
+
+		driver, err := getDriver(ctx, c, store.Driver.Name)
+		if err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return nil, err
+			}
+			continue
+		}
+
+		version, ok := getDriverVersion(driver, store.Driver.Version)
+		if !ok || version.Plugin == nil {
+			continue
+		}
+		plugins = append(plugins, driverPlugin{
+			driver: store.Driver,
+			plugin: *version.Plugin,
+		})
+	}
+	return plugins, nil
+}
+
+// getAllDriverPlugins returns the plugins for all versions of all registered drivers
+// Driver versions that do not declare a plugin are skipped.
+func getAllDriverPlugins(ctx context.Context, c client.Client) ([]driverPlugin, error) {
+	driverList := &atomixv1beta1.DriverList{}
+	if err := c.List(ctx, driverList); err != nil {
+		return nil, err
+	}
+
+	var plugins []driverPlugin
+	for _, driver := range driverList.Items {
+		for _, version := range driver.Spec.Versions {
+			if version.Plugin == nil {
+				continue
+			}
+			plugins = append(plugins, driverPlugin{
+				driver: atomixv1beta1.DriverReference{
+					Name:    driver.Name,
+					Version: version.Name,
+				},
+				plugin: *version.Plugin,
+			})
+		}
+	}
+	return plugins, nil
+}
+
+// getDriverPluginFile returns the name of the file from which the proxy loads the given driver's plugin
+func getDriverPluginFile(driver atomixv1beta1.DriverReference) string {
+	return fmt.Sprintf("%s@%s.so", driver.Name, driver.Version)
+}
+
+// newDriverPluginContainer returns an init container that installs the given driver plugin into the plugins volume
+func newDriverPluginContainer(plugin driverPlugin) corev1.Container {
+	pullPolicy := plugin.plugin.ImagePullPolicy
+	if pullPolicy == "" {
+		pullPolicy = corev1.PullIfNotPresent
+	}
+	return corev1.Container{
+		Name:            getDriverPluginContainerName(plugin.driver),
+		Image:           plugin.plugin.Image,
+		ImagePullPolicy: pullPolicy,
+		Command: []string{
+			"cp",
+			plugin.plugin.Path,
+			fmt.Sprintf("%s/%s", pluginsPath, getDriverPluginFile(plugin.driver)),
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      pluginsVolumeName,
+				MountPath: pluginsPath,
+			},
+		},
+	}
+}
+
+// getDriverPluginContainerName returns the name of the init container that installs the given driver's plugin
+func getDriverPluginContainerName(driver atomixv1beta1.DriverReference) string {
+	name := strings.ToLower(fmt.Sprintf("install-%s-%s", driver.Name, driver.Version))
+	name = strings.NewReplacer(".", "-", "_", "-", "@", "-").Replace(name)
+	if len(name) > 63 {
+		name = strings.TrimSuffix(name[:63], "-")
+	}
+	return name
+}
+
+// getDriverConfig returns the given store configuration merged with the defaults declared by the driver version.
+// The configuration is validated against the driver version's schema if one is declared.
+func getDriverConfig(version *atomixv1beta1.DriverVersion, raw []byte) ([]byte, error) {
+	config := make(map[string]interface{})
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &config); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(version.Defaults.Raw) > 0 {
+		defaults := make(map[string]interface{})
+		if err := json.Unmarshal(version.Defaults.Raw, &defaults); err != nil {
+			return nil, err
+		}
+		config = mergeConfig(defaults, config)
+	}
+
+	if version.Schema != nil {
+		schema := &apiextensions.JSONSchemaProps{}
+		if err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(version.Schema, schema, nil); err != nil {
+			return nil, err
+		}
+
+		structural, err := structuralschema.NewStructural(schema)
+		if err != nil {
+			return nil, err
+		}
+		structuraldefaulting.Default(config, structural)
+
+		validator, _, err := validation.NewSchemaValidator(&apiextensions.CustomResourceValidation{OpenAPIV3Schema: schema})
+		if err != nil {
+			return nil, err
+		}
+		if errs := validation.ValidateCustomResource(field.NewPath("spec", "config"), config, validator); len(errs) > 0 {
+			return nil, errs.ToAggregate()
+		}
+	}
+	return json.Marshal(config)
+}
+
+// mergeConfig recursively merges the given configuration into the given defaults
+func mergeConfig(defaults, config map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{})
+	for key, value := range defaults {
+		merged[key] = value
+	}
+	for key, value := range config {
+		if valueMap, ok := value.(map[string]interface{}); ok {
+			if defaultMap, ok := merged[key].(map[string]interface{}); ok {
+				merged[key] = mergeConfig(defaultMap, valueMap)
+				continue
+			}
+		}
+		merged[key] = value
+	}
+	return merged
+}
@@ -0,0 +1,218 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"context"
+	"encoding/json"
+	atomixv1beta1 "github.com/atomix/controller/pkg/apis/atomix/v1beta1"
+	admissionv1 "k8s.io/api/admission/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"testing"
+)
+
+func newTestDriverVersion() *atomixv1beta1.DriverVersion {
+	return &atomixv1beta1.DriverVersion{
+		Name: "v1",
+		Schema: &apiextensionsv1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensionsv1.JSONSchemaProps{
+				"replicas": {
+					Type:    "integer",
+					Minimum: &[]float64{1}[0],
+					Default: &apiextensionsv1.JSON{Raw: []byte(`3`)},
+				},
+				"server": {
+					Type: "object",
+					Properties: map[string]apiextensionsv1.JSONSchemaProps{
+						"host": {Type: "string"},
+						"port": {Type: "integer"},
+					},
+				},
+			},
+		},
+		Defaults: runtime.RawExtension{
+			Raw: []byte(`{"server":{"host":"localhost","port":8080}}`),
+		},
+	}
+}
+
+func TestGetDriverConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		version *atomixv1beta1.DriverVersion
+		config  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "no schema or defaults",
+			version: &atomixv1beta1.DriverVersion{Name: "v1"},
+			config:  `{"foo":"bar"}`,
+			want:    `{"foo":"bar"}`,
+		},
+		{
+			name:    "empty config",
+			version: newTestDriverVersion(),
+			want:    `{"replicas":3,"server":{"host":"localhost","port":8080}}`,
+		},
+		{
+			name:    "defaults merged into nested config",
+			version: newTestDriverVersion(),
+			config:  `{"replicas":5,"server":{"port":9090}}`,
+			want:    `{"replicas":5,"server":{"host":"localhost","port":9090}}`,
+		},
+		{
+			name:    "invalid type",
+			version: newTestDriverVersion(),
+			config:  `{"replicas":"five"}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid value",
+			version: newTestDriverVersion(),
+			config:  `{"replicas":0}`,
+			wantErr: true,
+		},
+		{
+			name:    "malformed config",
+			version: newTestDriverVersion(),
+			config:  `{`,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := getDriverConfig(test.version, []byte(test.config))
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("getDriverConfig() = %s, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertJSONEqual(t, got, []byte(test.want))
+		})
+	}
+}
+
+func TestStoreDefaulter(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := atomixv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	driver := &atomixv1beta1.Driver{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: atomixv1beta1.DriverSpec{
+			Versions: []atomixv1beta1.DriverVersion{*newTestDriverVersion()},
+		},
+	}
+	decoder, err := admission.NewDecoder(scheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defaulter := &StoreDefaulter{
+		client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(driver).Build(),
+		scheme: scheme,
+	}
+	if err := defaulter.InjectDecoder(decoder); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		driver      atomixv1beta1.DriverReference
+		config      string
+		wantAllowed bool
+		wantPatch   bool
+		wantWarning bool
+	}{
+		{
+			name:        "defaulted",
+			driver:      atomixv1beta1.DriverReference{Name: "test", Version: "v1"},
+			wantAllowed: true,
+			wantPatch:   true,
+		},
+		{
+			name:   "invalid config",
+			driver: atomixv1beta1.DriverReference{Name: "test", Version: "v1"},
+			config: `{"replicas":0}`,
+		},
+		{
+			name:   "unsupported version",
+			driver: atomixv1beta1.DriverReference{Name: "test", Version: "v2"},
+		},
+		{
+			name:        "unregistered driver",
+			driver:      atomixv1beta1.DriverReference{Name: "unknown", Version: "v1"},
+			wantAllowed: true,
+			wantWarning: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := &atomixv1beta1.Store{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: atomixv1beta1.SchemeGroupVersion.String(),
+					Kind:       storeKind,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test",
+					Name:      "test",
+				},
+				Spec: atomixv1beta1.StoreSpec{
+					Driver: test.driver,
+				},
+			}
+			if test.config != "" {
+				store.Spec.Config.Raw = []byte(test.config)
+			}
+			raw, err := json.Marshal(store)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			response := defaulter.Handle(context.TODO(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Kind:      metav1.GroupVersionKind{Group: atomixv1beta1.SchemeGroupVersion.Group, Version: atomixv1beta1.SchemeGroupVersion.Version, Kind: storeKind},
+					Operation: admissionv1.Create,
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			})
+			if response.Allowed != test.wantAllowed {
+				t.Errorf("Allowed = %t, want %t", response.Allowed, test.wantAllowed)
+			}
+			if hasPatch := len(response.Patches) > 0; hasPatch != test.wantPatch {
+				t.Errorf("patched = %t, want %t", hasPatch, test.wantPatch)
+			}
+			if hasWarning := len(response.Warnings) > 0; hasWarning != test.wantWarning {
+				t.Errorf("warned = %t, want %t", hasWarning, test.wantWarning)
+			}
+		})
+	}
+}
+
+func assertJSONEqual(t *testing.T, got, want []byte) {
+	t.Helper()
+	var gotValue, wantValue interface{}
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(want, &wantValue); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
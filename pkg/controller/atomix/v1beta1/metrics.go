@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"context"
+	atomixv1beta1 "github.com/atomix/controller/pkg/apis/atomix/v1beta1"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"net/http"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"time"
+)
+
+const metricsNamespace = "atomix"
+
+const (
+	injectionInjected = "injected"
+	injectionSkipped  = "skipped"
+	injectionDenied   = "denied"
+	injectionErrored  = "errored"
+)
+
+const (
+	connectOperation    = "connect"
+	configureOperation  = "configure"
+	disconnectOperation = "disconnect"
+)
+
+const bindingMetricsTimeout = 5 * time.Second
+
+var (
+	injectionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "injector",
+		Name:      "requests_total",
+		Help:      "Number of proxy injection admission requests by outcome",
+	}, []string{"outcome"})
+
+	proxyRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "proxy",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of proxy control requests by operation, store and driver",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation", "store", "driver", "version"})
+
+	proxyRequestErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "proxy",
+		Name:      "request_errors_total",
+		Help:      "Number of failed proxy control requests by operation, store and driver",
+	}, []string{"operation", "store", "driver", "version"})
+
+	podReadyDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "pod",
+		Name:      "ready_duration_seconds",
+		Help:      "Time from pod creation until the AtomixReady condition is true",
+		Buckets:   prometheus.ExponentialBuckets(0.5, 2, 12),
+	})
+
+	bindingsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "proxy", "bindings"),
+		"Number of proxy bindings by namespace, profile, store and state",
+		[]string{"namespace", "profile_kind", "profile", "store", "state"}, nil)
+)
+
+func init() {
+	metrics.Registry.MustRegister(injectionsTotal, proxyRequestDuration, proxyRequestErrors, podReadyDuration)
+}
+
+// getInjectionOutcome returns the outcome of a proxy injection for the given admission response
+func getInjectionOutcome(response admission.Response) string {
+	if response.Allowed {
+		if len(response.Patches) > 0 {
+			return injectionInjected
+		}
+		return injectionSkipped
+	}
+	if response.Result != nil && response.Result.Code == http.StatusForbidden {
+		return injectionDenied
+	}
+	return injectionErrored
+}
+
+// observeProxyRequest records the latency and outcome of a proxy control request
+func observeProxyRequest(operation string, storeID types.NamespacedName, driver atomixv1beta1.DriverReference, start time.Time, err error) {
+	store := getStoreLabel(storeID)
+	proxyRequestDuration.WithLabelValues(operation, store, driver.Name, driver.Version).Observe(time.Since(start).Seconds())
+	if err != nil {
+		proxyRequestErrors.WithLabelValues(operation, store, driver.Name, driver.Version).Inc()
+	}
+}
+
+func getStoreLabel(storeID types.NamespacedName) string {
+	if storeID.Namespace == "" {
+		return storeID.Name
+	}
+	return storeID.String()
+}
+
+// newBindingCollector returns a collector reporting the state of proxy bindings from the given client's cache
+func newBindingCollector(client client.Client) prometheus.Collector {
+	return &bindingCollector{
+		client: client,
+	}
+}
+
+type bindingCollector struct {
+	client client.Client
+}
+
+func (c *bindingCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- bindingsDesc
+}
+
+type bindingKey struct {
+	namespace   string
+	profileKind string
+	profile     string
+	store       string
+	state       atomixv1beta1.BindingState
+}
+
+func (c *bindingCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), bindingMetricsTimeout)
+	defer cancel()
+
+	proxies := &atomixv1beta1.ProxyList{}
+	if err := c.client.List(ctx, proxies); err != nil {
+		log.Error(err)
+		return
+	}
+
+	counts := make(map[bindingKey]int)
+	profiles := make(map[bindingKey]map[string]types.NamespacedName)
+	for _, proxy := range proxies.Items {
+		kind := proxy.Profile.Kind
+		if kind == "" {
+			kind = profileKind
+		}
+
+		// Resolve the stores referenced by the proxy's profile bindings
+		profileID := bindingKey{
+			namespace:   proxy.Namespace,
+			profileKind: kind,
+			profile:     proxy.Profile.Name,
+		}
+		stores, ok := profiles[profileID]
+		if !ok {
+			stores = make(map[string]types.NamespacedName)
+			if spec, err := getProfileSpec(ctx, c.client, proxy.Namespace, proxy.Profile); err == nil {
+				for _, binding := range spec.Bindings {
+					stores[binding.Name] = getStoreID(proxy.Namespace, binding.Store)
+				}
+			}
+			profiles[profileID] = stores
+		}
+
+		for _, binding := range proxy.Status.Bindings {
+			key := bindingKey{
+				namespace:   proxy.Namespace,
+				profileKind: kind,
+				profile:     proxy.Profile.Name,
+				store:       getStoreLabel(stores[binding.Name]),
+				state:       binding.State,
+			}
+			counts[key]++
+		}
+	}
+
+	for key, count := range counts {
+		ch <- prometheus.MustNewConstMetric(bindingsDesc, prometheus.GaugeValue, float64(count),
+			key.namespace, key.profileKind, key.profile, key.store, string(key.state))
+	}
+}
@@ -0,0 +1,251 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	atomixv1beta1 "github.com/atomix/controller/pkg/apis/atomix/v1beta1"
+	controllerconfig "github.com/atomix/controller/pkg/controller/config"
+	"github.com/atomix/controller/pkg/controller/util/k8s"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	namespaceNameLabel  = "kubernetes.io/metadata.name"
+	controllerNameLabel = "name"
+)
+
+const maxPort = 65535
+
+// newConfigSource returns a source enqueueing the requests returned by the given function when the configuration changes
+func newConfigSource(controllerConfig *controllerconfig.Watcher, getRequests func(ctx context.Context) ([]reconcile.Request, error)) source.Source {
+	return source.Func(func(ctx context.Context, _ handler.EventHandler, queue workqueue.RateLimitingInterface, _ ...predicate.Predicate) error {
+		controllerConfig.Watch(func(controllerconfig.Config) {
+			requests, err := getRequests(ctx)
+			if err != nil {
+				log.Error(err)
+				return
+			}
+			for _, request := range requests {
+				queue.Add(request)
+			}
+		})
+		return nil
+	})
+}
+
+// getProfileNetworkPolicyName returns the name of the NetworkPolicy protecting the proxies of the given profile
+func getProfileNetworkPolicyName(ref atomixv1beta1.ProfileReference) string {
+	return truncateName(fmt.Sprintf("%s-proxy", getProfileConfigMapName(ref)), validation.DNS1123SubdomainMaxLength)
+}
+
+// reconcileProfileNetworkPolicy creates, updates or deletes the NetworkPolicy for the given profile in the given namespace
+// The policy is owned by the profile, so it's deleted by the garbage collector when the profile is deleted.
+func reconcileProfileNetworkPolicy(ctx context.Context, c client.Client, scheme *runtime.Scheme, owner client.Object, namespace string, ref atomixv1beta1.ProfileReference, config controllerconfig.NetworkPolicyConfig) error {
+	networkPolicyName := types.NamespacedName{
+		Namespace: namespace,
+		Name:      getProfileNetworkPolicyName(ref),
+	}
+	return reconcileNetworkPolicy(ctx, c, scheme, owner, newProfileNetworkPolicy(networkPolicyName, ref, config), config.Enabled)
+}
+
+// reconcileNetworkPolicy creates or updates the given NetworkPolicy controlled by the given owner if enabled, or deletes it otherwise
+func reconcileNetworkPolicy(ctx context.Context, c client.Client, scheme *runtime.Scheme, owner client.Object, desired *networkingv1.NetworkPolicy, enabled bool) error {
+	networkPolicyName := getNamespacedName(desired)
+	networkPolicy := &networkingv1.NetworkPolicy{}
+	if err := c.Get(ctx, networkPolicyName, networkPolicy); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			log.Error(err)
+			return err
+		}
+		if !enabled {
+			return nil
+		}
+
+		if err := controllerutil.SetControllerReference(owner, desired, scheme); err != nil {
+			log.Error(err)
+			return err
+		}
+
+		log.Infof("Creating NetworkPolicy '%s'", networkPolicyName)
+		if err := c.Create(ctx, desired); err != nil && !k8serrors.IsAlreadyExists(err) {
+			log.Error(err)
+			return err
+		}
+		return nil
+	}
+
+	// Never modify policies not created by the controller
+	if !metav1.IsControlledBy(networkPolicy, owner) {
+		log.Warnf("NetworkPolicy '%s' is not owned by '%s'", networkPolicyName, getNamespacedName(owner))
+		return nil
+	}
+
+	if !enabled {
+		log.Infof("Deleting NetworkPolicy '%s'", networkPolicyName)
+		if err := c.Delete(ctx, networkPolicy); err != nil && !k8serrors.IsNotFound(err) {
+			log.Error(err)
+			return err
+		}
+		return nil
+	}
+
+	if !equality.Semantic.DeepDerivative(desired.Spec, networkPolicy.Spec) {
+		log.Infof("Updating NetworkPolicy '%s'", networkPolicyName)
+		networkPolicy.Spec = desired.Spec
+		if err := c.Update(ctx, networkPolicy); err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+	return nil
+}
+
+// newProfileNetworkPolicy returns a NetworkPolicy restricting ingress to the proxies of the given profile
+// Only the controller's pods are allowed to reach the proxy's control port. No ingress is allowed to the
+// runtime port, which remains reachable from the application's containers over the pod's loopback interface.
+func newProfileNetworkPolicy(name types.NamespacedName, ref atomixv1beta1.ProfileReference, config controllerconfig.NetworkPolicyConfig) *networkingv1.NetworkPolicy {
+	rules := []networkingv1.NetworkPolicyIngressRule{
+		newControlPortIngressRule(),
+	}
+
+	// Selecting the pods isolates all of their ports, so unless the application's ports are to be
+	// isolated as well, allow ingress from any source to all ports other than the proxy's ports.
+	if !config.IsolateApplicationPorts {
+		rules = append(rules, networkingv1.NetworkPolicyIngressRule{
+			Ports: newPortRangesExcluding(defaultRuntimePort, defaultProxyPort),
+		})
+	}
+
+	return newProxyNetworkPolicy(name, map[string]string{
+		proxyInjectedLabel:    "true",
+		proxyProfileLabel:     getProfileLabelValue(ref.Name),
+		proxyProfileKindLabel: ref.Kind,
+	}, rules)
+}
+
+// getProxyPoolNetworkPolicyName returns the name of the NetworkPolicy protecting the proxies of the given pool
+func getProxyPoolNetworkPolicyName(proxyPool *atomixv1beta1.ProxyPool) string {
+	return truncateName(fmt.Sprintf("proxy-pool.%s", proxyPool.Name), validation.DNS1123SubdomainMaxLength)
+}
+
+// newProxyPoolNetworkPolicy returns a NetworkPolicy restricting ingress to the proxies of the given pool
+// Only the controller's pods are allowed to reach the proxy's control port, while the runtime port is open
+// to the clients connecting through the pool's Service. The pool's pods run no other containers.
+func newProxyPoolNetworkPolicy(proxyPool *atomixv1beta1.ProxyPool) *networkingv1.NetworkPolicy {
+	tcp := corev1.ProtocolTCP
+	runtimePort := intstr.FromInt(defaultRuntimePort)
+	name := types.NamespacedName{
+		Namespace: proxyPool.Namespace,
+		Name:      getProxyPoolNetworkPolicyName(proxyPool),
+	}
+	return newProxyNetworkPolicy(name, getProxyPoolLabels(proxyPool), []networkingv1.NetworkPolicyIngressRule{
+		newControlPortIngressRule(),
+		{
+			Ports: []networkingv1.NetworkPolicyPort{
+				{
+					Protocol: &tcp,
+					Port:     &runtimePort,
+				},
+			},
+		},
+	})
+}
+
+// newProxyNetworkPolicy returns a NetworkPolicy allowing the given ingress to the pods matching the given labels
+func newProxyNetworkPolicy(name types.NamespacedName, matchLabels map[string]string, rules []networkingv1.NetworkPolicyIngressRule) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: name.Namespace,
+			Name:      name.Name,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: matchLabels,
+			},
+			PolicyTypes: []networkingv1.PolicyType{
+				networkingv1.PolicyTypeIngress,
+			},
+			Ingress: rules,
+		},
+	}
+}
+
+// newControlPortIngressRule returns an ingress rule allowing only the controller's pods to reach the proxy's control port
+func newControlPortIngressRule() networkingv1.NetworkPolicyIngressRule {
+	tcp := corev1.ProtocolTCP
+	controlPort := intstr.FromInt(defaultProxyPort)
+	return networkingv1.NetworkPolicyIngressRule{
+		From: []networkingv1.NetworkPolicyPeer{
+			{
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						namespaceNameLabel: k8s.GetNamespace(),
+					},
+				},
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						controllerNameLabel: k8s.GetName(),
+					},
+				},
+			},
+		},
+		Ports: []networkingv1.NetworkPolicyPort{
+			{
+				Protocol: &tcp,
+				Port:     &controlPort,
+			},
+		},
+	}
+}
+
+// getProfileLabelValue returns the value of the profile label for the given profile name
+// Profile names may be longer than label values, so long names are truncated and suffixed with a hash.
+func getProfileLabelValue(name string) string {
+	return truncateName(name, validation.LabelValueMaxLength)
+}
+
+// newPortRangesExcluding returns the ports of all protocols except the given range of TCP ports
+func newPortRangesExcluding(startPort, endPort int32) []networkingv1.NetworkPolicyPort {
+	tcp := corev1.ProtocolTCP
+	udp := corev1.ProtocolUDP
+	sctp := corev1.ProtocolSCTP
+	lowerStart, lowerEnd := intstr.FromInt(1), startPort-1
+	upperStart, upperEnd := intstr.FromInt(int(endPort+1)), int32(maxPort)
+	return []networkingv1.NetworkPolicyPort{
+		{
+			Protocol: &tcp,
+			Port:     &lowerStart,
+			EndPort:  &lowerEnd,
+		},
+		{
+			Protocol: &tcp,
+			Port:     &upperStart,
+			EndPort:  &upperEnd,
+		},
+		{
+			Protocol: &udp,
+		},
+		{
+			Protocol: &sctp,
+		},
+	}
+}
@@ -0,0 +1,350 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"context"
+	atomixv1beta1 "github.com/atomix/controller/pkg/apis/atomix/v1beta1"
+	controllerconfig "github.com/atomix/controller/pkg/controller/config"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"strings"
+	"testing"
+)
+
+func TestTruncateName(t *testing.T) {
+	long := strings.Repeat("a", validation.LabelValueMaxLength)
+	tests := []struct {
+		name      string
+		input     string
+		maxLength int
+		want      string
+	}{
+		{name: "short name", input: "foo", maxLength: validation.LabelValueMaxLength, want: "foo"},
+		{name: "max length", input: long, maxLength: validation.LabelValueMaxLength, want: long},
+		{name: "long name", input: long + "b", maxLength: validation.LabelValueMaxLength},
+		{name: "long name with separators", input: strings.Repeat("a-.", 30), maxLength: validation.LabelValueMaxLength},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := truncateName(test.input, test.maxLength)
+			if test.want != "" && got != test.want {
+				t.Errorf("truncateName() = %s, want %s", got, test.want)
+			}
+			if len(got) > test.maxLength {
+				t.Errorf("len(truncateName()) = %d, want <= %d", len(got), test.maxLength)
+			}
+			if errs := validation.IsValidLabelValue(got); len(errs) > 0 {
+				t.Errorf("truncateName() = %s is not a valid label value: %v", got, errs)
+			}
+			if got != truncateName(test.input, test.maxLength) {
+				t.Errorf("truncateName() is not deterministic")
+			}
+		})
+	}
+}
+
+func TestGetProfileLabelValue(t *testing.T) {
+	prefix := strings.Repeat("a", validation.LabelValueMaxLength)
+	names := []string{prefix + "b", prefix + "c", prefix + "bb"}
+	values := make(map[string]string)
+	for _, name := range names {
+		value := getProfileLabelValue(name)
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			t.Errorf("getProfileLabelValue(%s) = %s is not a valid label value: %v", name, value, errs)
+		}
+		if other, ok := values[value]; ok {
+			t.Errorf("getProfileLabelValue(%s) = getProfileLabelValue(%s) = %s", name, other, value)
+		}
+		values[value] = name
+	}
+}
+
+func TestNewPortRangesExcluding(t *testing.T) {
+	tests := []struct {
+		name      string
+		startPort int32
+		endPort   int32
+		excluded  []int32
+		included  []int32
+	}{
+		{
+			name:      "proxy ports",
+			startPort: defaultRuntimePort,
+			endPort:   defaultProxyPort,
+			excluded:  []int32{defaultRuntimePort, defaultProxyPort},
+			included:  []int32{1, defaultRuntimePort - 1, defaultProxyPort + 1, maxPort},
+		},
+		{
+			name:      "single port",
+			startPort: 8080,
+			endPort:   8080,
+			excluded:  []int32{8080},
+			included:  []int32{8079, 8081},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ports := newPortRangesExcluding(test.startPort, test.endPort)
+			for _, port := range test.excluded {
+				if isTCPPortAllowed(ports, port) {
+					t.Errorf("TCP port %d is allowed", port)
+				}
+			}
+			for _, port := range test.included {
+				if !isTCPPortAllowed(ports, port) {
+					t.Errorf("TCP port %d is not allowed", port)
+				}
+			}
+			for _, protocol := range []corev1.Protocol{corev1.ProtocolUDP, corev1.ProtocolSCTP} {
+				if !isProtocolAllowed(ports, protocol) {
+					t.Errorf("protocol %s is not allowed", protocol)
+				}
+			}
+		})
+	}
+}
+
+func TestNewProfileNetworkPolicy(t *testing.T) {
+	t.Setenv("CONTROLLER_NAME", "atomix-controller")
+	name := types.NamespacedName{Namespace: "test", Name: "test"}
+	tests := []struct {
+		name          string
+		ref           atomixv1beta1.ProfileReference
+		config        controllerconfig.NetworkPolicyConfig
+		wantRules     int
+		wantLabelName string
+	}{
+		{
+			name:          "profile",
+			ref:           atomixv1beta1.ProfileReference{Kind: profileKind, Name: "test"},
+			wantRules:     2,
+			wantLabelName: "test",
+		},
+		{
+			name:          "isolated application ports",
+			ref:           atomixv1beta1.ProfileReference{Kind: clusterProfileKind, Name: "test"},
+			config:        controllerconfig.NetworkPolicyConfig{IsolateApplicationPorts: true},
+			wantRules:     1,
+			wantLabelName: "test",
+		},
+		{
+			name:          "long profile name",
+			ref:           atomixv1beta1.ProfileReference{Kind: profileKind, Name: strings.Repeat("a", 100)},
+			wantRules:     2,
+			wantLabelName: truncateName(strings.Repeat("a", 100), validation.LabelValueMaxLength),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			networkPolicy := newProfileNetworkPolicy(name, test.ref, test.config)
+			wantLabels := map[string]string{
+				proxyInjectedLabel:    "true",
+				proxyProfileLabel:     test.wantLabelName,
+				proxyProfileKindLabel: test.ref.Kind,
+			}
+			if got := networkPolicy.Spec.PodSelector.MatchLabels; !reflect.DeepEqual(got, wantLabels) {
+				t.Errorf("selector = %v, want %v", got, wantLabels)
+			}
+			if got := len(networkPolicy.Spec.Ingress); got != test.wantRules {
+				t.Errorf("len(ingress) = %d, want %d", got, test.wantRules)
+			}
+			if !reflect.DeepEqual(networkPolicy.Spec.Ingress[0], newControlPortIngressRule()) {
+				t.Errorf("ingress[0] = %+v, want the control port rule", networkPolicy.Spec.Ingress[0])
+			}
+		})
+	}
+}
+
+func TestNewProxyPoolNetworkPolicy(t *testing.T) {
+	t.Setenv("CONTROLLER_NAME", "atomix-controller")
+	tests := []struct {
+		name      string
+		proxyPool string
+	}{
+		{name: "pool", proxyPool: "test"},
+		{name: "long pool name", proxyPool: strings.Repeat("a", 100)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			proxyPool := &atomixv1beta1.ProxyPool{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test",
+					Name:      test.proxyPool,
+				},
+			}
+			networkPolicy := newProxyPoolNetworkPolicy(proxyPool)
+			if networkPolicy.Namespace != proxyPool.Namespace {
+				t.Errorf("namespace = %s, want %s", networkPolicy.Namespace, proxyPool.Namespace)
+			}
+			if errs := validation.IsDNS1123Subdomain(networkPolicy.Name); len(errs) > 0 {
+				t.Errorf("name %s is invalid: %v", networkPolicy.Name, errs)
+			}
+			if got, want := networkPolicy.Spec.PodSelector.MatchLabels, getProxyPoolLabels(proxyPool); !reflect.DeepEqual(got, want) {
+				t.Errorf("selector = %v, want %v", got, want)
+			}
+			for _, value := range networkPolicy.Spec.PodSelector.MatchLabels {
+				if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+					t.Errorf("label value %s is invalid: %v", value, errs)
+				}
+			}
+
+			var controlPortRules, runtimePortRules int
+			for _, rule := range networkPolicy.Spec.Ingress {
+				if isTCPPortAllowed(rule.Ports, defaultProxyPort) {
+					controlPortRules++
+					if !reflect.DeepEqual(rule, newControlPortIngressRule()) {
+						t.Errorf("control port rule = %+v, want %+v", rule, newControlPortIngressRule())
+					}
+				}
+				if isTCPPortAllowed(rule.Ports, defaultRuntimePort) {
+					runtimePortRules++
+					if len(rule.From) > 0 {
+						t.Errorf("runtime port rule is restricted to %+v", rule.From)
+					}
+				}
+			}
+			if controlPortRules != 1 || runtimePortRules != 1 {
+				t.Errorf("rules = %+v, want one control port rule and one runtime port rule", networkPolicy.Spec.Ingress)
+			}
+		})
+	}
+}
+
+func TestReconcileNetworkPolicy(t *testing.T) {
+	t.Setenv("CONTROLLER_NAME", "atomix-controller")
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := atomixv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	proxyPool := &atomixv1beta1.ProxyPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "test",
+			UID:       "1234",
+		},
+	}
+	newOwnedNetworkPolicy := func() *networkingv1.NetworkPolicy {
+		networkPolicy := newProxyPoolNetworkPolicy(proxyPool)
+		networkPolicy.OwnerReferences = []metav1.OwnerReference{
+			*metav1.NewControllerRef(proxyPool, atomixv1beta1.SchemeGroupVersion.WithKind("ProxyPool")),
+		}
+		return networkPolicy
+	}
+
+	tests := []struct {
+		name       string
+		existing   func() *networkingv1.NetworkPolicy
+		enabled    bool
+		wantExists bool
+		wantOwned  bool
+	}{
+		{
+			name:       "create",
+			enabled:    true,
+			wantExists: true,
+			wantOwned:  true,
+		},
+		{
+			name: "update",
+			existing: func() *networkingv1.NetworkPolicy {
+				networkPolicy := newOwnedNetworkPolicy()
+				networkPolicy.Spec.Ingress = nil
+				return networkPolicy
+			},
+			enabled:    true,
+			wantExists: true,
+			wantOwned:  true,
+		},
+		{
+			name:    "disabled",
+			enabled: false,
+		},
+		{
+			name:     "delete",
+			existing: newOwnedNetworkPolicy,
+			enabled:  false,
+		},
+		{
+			name:       "not owned",
+			existing:   func() *networkingv1.NetworkPolicy { return newProxyPoolNetworkPolicy(proxyPool) },
+			enabled:    false,
+			wantExists: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(proxyPool.DeepCopy())
+			if test.existing != nil {
+				builder = builder.WithObjects(test.existing())
+			}
+			c := builder.Build()
+
+			desired := newProxyPoolNetworkPolicy(proxyPool)
+			if err := reconcileNetworkPolicy(context.TODO(), c, scheme, proxyPool, desired.DeepCopy(), test.enabled); err != nil {
+				t.Fatal(err)
+			}
+
+			networkPolicy := &networkingv1.NetworkPolicy{}
+			err := c.Get(context.TODO(), getNamespacedName(desired), networkPolicy)
+			if k8serrors.IsNotFound(err) {
+				if test.wantExists {
+					t.Errorf("NetworkPolicy was not found")
+				}
+				return
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			if !test.wantExists {
+				t.Fatalf("NetworkPolicy was not deleted")
+			}
+			if owned := metav1.IsControlledBy(networkPolicy, proxyPool); owned != test.wantOwned {
+				t.Errorf("owned = %t, want %t", owned, test.wantOwned)
+			}
+			if test.wantOwned && !reflect.DeepEqual(networkPolicy.Spec, desired.Spec) {
+				t.Errorf("spec = %+v, want %+v", networkPolicy.Spec, desired.Spec)
+			}
+		})
+	}
+}
+
+func isTCPPortAllowed(ports []networkingv1.NetworkPolicyPort, port int32) bool {
+	for _, p := range ports {
+		if p.Protocol == nil || *p.Protocol != corev1.ProtocolTCP {
+			continue
+		}
+		if p.Port == nil {
+			return true
+		}
+		endPort := p.Port.IntVal
+		if p.EndPort != nil {
+			endPort = *p.EndPort
+		}
+		if port >= p.Port.IntVal && port <= endPort {
+			return true
+		}
+	}
+	return false
+}
+
+func isProtocolAllowed(ports []networkingv1.NetworkPolicyPort, protocol corev1.Protocol) bool {
+	for _, p := range ports {
+		if p.Protocol != nil && *p.Protocol == protocol && p.Port == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,824 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	atomixv1beta1 "github.com/atomix/controller/pkg/apis/atomix/v1beta1"
+	controllerconfig "github.com/atomix/controller/pkg/controller/config"
+	"github.com/atomix/controller/pkg/controller/util/k8s"
+	"github.com/atomix/proxy/pkg/proxy"
+	"google.golang.org/grpc/credentials/insecure"
+	"gopkg.in/yaml.v3"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	nodeProxyHostEnv  = "ATOMIX_PROXY_HOST"
+	nodeProxyPortEnv  = "ATOMIX_PROXY_PORT"
+	nodeProxyPodIDEnv = "ATOMIX_POD_ID"
+)
+
+const (
+	nodeProxyLabel     = "proxy.atomix.io/node-proxy"
+	nodeProxyFinalizer = "proxy.atomix.io/node-bindings"
+)
+
+const nodeProxyConfigChecksumAnnotation = "proxy.atomix.io/config-checksum"
+
+const podNodeIndex = "spec.nodeName"
+
+const nodeProxyReleaseTimeout = 10 * time.Second
+
+func addNodeProxyController(mgr manager.Manager, controllerConfig *controllerconfig.Watcher) error {
+	controllerOptions := controllerConfig.Get().GetController("node-proxy-controller")
+
+	// Create a new controller
+	c, err := controller.New("node-proxy-controller", mgr, controller.Options{
+		Reconciler: &NodeProxyReconciler{
+			client:           mgr.GetClient(),
+			reader:           mgr.GetAPIReader(),
+			scheme:           mgr.GetScheme(),
+			controllerConfig: controllerConfig,
+		},
+		MaxConcurrentReconciles: controllerOptions.MaxConcurrentReconciles,
+		RateLimiter:             newRateLimiter(controllerOptions.RateLimiter),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Reconcile the node proxy DaemonSet on startup and when the proxy configuration changes
+	err = c.Watch(source.Func(func(ctx context.Context, _ handler.EventHandler, queue workqueue.RateLimitingInterface, _ ...predicate.Predicate) error {
+		request := reconcile.Request{
+			NamespacedName: getNodeProxyName(),
+		}
+		controllerConfig.Watch(func(controllerconfig.Config) {
+			queue.Add(request)
+		})
+		queue.Add(request)
+		return nil
+	}), &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to pods injected in node mode, which prevent the DaemonSet from being deleted
+	// and determine the profiles routed by the node proxies
+	err = c.Watch(&source.Kind{Type: &corev1.Pod{}}, handler.EnqueueRequestsFromMapFunc(func(object client.Object) []reconcile.Request {
+		if !isNodeProxyPod(object.(*corev1.Pod)) {
+			return nil
+		}
+		return []reconcile.Request{
+			{
+				NamespacedName: getNodeProxyName(),
+			},
+		}
+	}))
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to Drivers, which determine the plugins installed in the node proxies
+	err = c.Watch(&source.Kind{Type: &atomixv1beta1.Driver{}}, handler.EnqueueRequestsFromMapFunc(getNodeProxyRequests))
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to Profiles, which determine the routes configured in the node proxies
+	err = c.Watch(&source.Kind{Type: &atomixv1beta1.Profile{}}, handler.EnqueueRequestsFromMapFunc(getNodeProxyRequests))
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterProfiles, which determine the routes configured in the node proxies
+	err = c.Watch(&source.Kind{Type: &atomixv1beta1.ClusterProfile{}}, handler.EnqueueRequestsFromMapFunc(getNodeProxyRequests))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// getNodeProxyRequests returns a request for the node proxy DaemonSet
+func getNodeProxyRequests(client.Object) []reconcile.Request {
+	return []reconcile.Request{
+		{
+			NamespacedName: getNodeProxyName(),
+		},
+	}
+}
+
+// NodeProxyReconciler is a Reconciler for the node proxy DaemonSet
+type NodeProxyReconciler struct {
+	client           client.Client
+	reader           client.Reader
+	scheme           *runtime.Scheme
+	controllerConfig *controllerconfig.Watcher
+}
+
+// Reconcile reconciles the node proxy DaemonSet
+// The DaemonSet is read from the API server, since the controller's namespace may not be watched.
+func (r *NodeProxyReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log.Infof("Reconciling DaemonSet '%s'", request.NamespacedName)
+	proxyConfig := r.controllerConfig.Get().Proxy
+
+	daemonSet := &appsv1.DaemonSet{}
+	if err := r.reader.Get(ctx, request.NamespacedName, daemonSet); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			log.Error(err)
+			return reconcile.Result{}, err
+		}
+		if proxyConfig.Mode != controllerconfig.NodeProxyMode {
+			return reconcile.Result{}, nil
+		}
+
+		configMap, err := r.newConfigMap(ctx, request.NamespacedName)
+		if err != nil {
+			log.Error(err)
+			return reconcile.Result{}, err
+		}
+		daemonSet, err = r.newDaemonSet(ctx, request.NamespacedName, proxyConfig, configMap)
+		if err != nil {
+			log.Error(err)
+			return reconcile.Result{}, err
+		}
+		log.Infof("Creating DaemonSet '%s'", request.NamespacedName)
+		if err := r.client.Create(ctx, daemonSet); err != nil {
+			if k8serrors.IsAlreadyExists(err) {
+				return reconcile.Result{Requeue: true}, nil
+			}
+			log.Error(err)
+			return reconcile.Result{}, err
+		}
+
+		// The configuration is owned by the DaemonSet, so it's deleted along with it
+		if err := reconcileConfigMap(ctx, r.client, r.reader, r.scheme, daemonSet, configMap); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, nil
+	}
+
+	// Only modify DaemonSets created by the controller
+	if daemonSet.Labels[nodeProxyLabel] != request.Name {
+		log.Warnf("DaemonSet '%s' is not managed by the controller", request.NamespacedName)
+		return reconcile.Result{}, nil
+	}
+
+	if proxyConfig.Mode != controllerconfig.NodeProxyMode {
+		// Keep the DaemonSet until the pods injected in node mode are deleted, since they depend on its proxies
+		if ok, err := r.hasNodeProxyPods(ctx); err != nil {
+			log.Error(err)
+			return reconcile.Result{}, err
+		} else if ok {
+			log.Warnf("Keeping DaemonSet '%s' until pods injected in node mode are deleted", request.NamespacedName)
+			return reconcile.Result{}, nil
+		}
+
+		log.Infof("Deleting DaemonSet '%s'", request.NamespacedName)
+		if err := r.client.Delete(ctx, daemonSet); err != nil && !k8serrors.IsNotFound(err) {
+			log.Error(err)
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, nil
+	}
+
+	// Update the configuration before the DaemonSet, so restarted proxies load the new routes
+	configMap, err := r.newConfigMap(ctx, request.NamespacedName)
+	if err != nil {
+		log.Error(err)
+		return reconcile.Result{}, err
+	}
+	if err := reconcileConfigMap(ctx, r.client, r.reader, r.scheme, daemonSet, configMap); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	desired, err := r.newDaemonSet(ctx, request.NamespacedName, proxyConfig, configMap)
+	if err != nil {
+		log.Error(err)
+		return reconcile.Result{}, err
+	}
+	if !equality.Semantic.DeepDerivative(desired.Spec.Template, daemonSet.Spec.Template) {
+		log.Infof("Updating DaemonSet '%s'", request.NamespacedName)
+		daemonSet.Spec.Template = desired.Spec.Template
+		if err := r.client.Update(ctx, daemonSet); err != nil {
+			log.Error(err)
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{}, nil
+}
+
+// hasNodeProxyPods returns whether any injected pods depend on the node proxies
+func (r *NodeProxyReconciler) hasNodeProxyPods(ctx context.Context) (bool, error) {
+	pods, err := r.getNodeProxyPods(ctx)
+	if err != nil {
+		return false, err
+	}
+	return len(pods) > 0, nil
+}
+
+// getNodeProxyPods returns the injected pods using the node proxies
+func (r *NodeProxyReconciler) getNodeProxyPods(ctx context.Context) ([]corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	if err := r.client.List(ctx, podList); err != nil {
+		return nil, err
+	}
+	var pods []corev1.Pod
+	for _, pod := range podList.Items {
+		if isNodeProxyPod(&pod) {
+			pods = append(pods, pod)
+		}
+	}
+	return pods, nil
+}
+
+// newConfigMap returns the node proxy configuration, routing the primitives of all the profiles used by pods
+// in node mode. Each node proxy is configured with the routes of all profiles, since any pod may be scheduled
+// on any node.
+func (r *NodeProxyReconciler) newConfigMap(ctx context.Context, name types.NamespacedName) (*corev1.ConfigMap, error) {
+	pods, err := r.getNodeProxyPods(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var profiles []nodeProfile
+	for _, pod := range pods {
+		ref, ok := getPodProfile(&pod)
+		if !ok {
+			continue
+		}
+		spec, err := getProfileSpec(ctx, r.client, pod.Namespace, ref)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		profiles = append(profiles, nodeProfile{
+			namespace: pod.Namespace,
+			ref:       ref,
+			spec:      *spec,
+		})
+	}
+
+	configBytes, err := yaml.Marshal(newNodeRouterConfig(profiles))
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: name.Namespace,
+			Name:      name.Name,
+			Labels: map[string]string{
+				nodeProxyLabel: name.Name,
+			},
+		},
+		BinaryData: map[string][]byte{
+			configFile: configBytes,
+		},
+	}, nil
+}
+
+// nodeProfile is a profile used by pods in the given namespace
+type nodeProfile struct {
+	namespace string
+	ref       atomixv1beta1.ProfileReference
+	spec      atomixv1beta1.ProfileSpec
+}
+
+// newNodeRouterConfig returns the router configuration merging the routes of the given profiles
+// Routes are ordered by namespace and profile so the configuration is stable, and duplicate routes are omitted.
+// A primitive is routed to the store of the first matching route, regardless of the namespace of the pod using it.
+func newNodeRouterConfig(profiles []nodeProfile) proxy.RouterConfig {
+	sort.Slice(profiles, func(i, j int) bool {
+		if profiles[i].namespace != profiles[j].namespace {
+			return profiles[i].namespace < profiles[j].namespace
+		}
+		if profiles[i].ref.Kind != profiles[j].ref.Kind {
+			return profiles[i].ref.Kind < profiles[j].ref.Kind
+		}
+		return profiles[i].ref.Name < profiles[j].ref.Name
+	})
+
+	var routerConfig proxy.RouterConfig
+	for _, profile := range profiles {
+		for _, route := range newRouterConfig(profile.namespace, profile.spec).Routes {
+			if !hasRoute(routerConfig, route) {
+				routerConfig.Routes = append(routerConfig.Routes, route)
+			}
+		}
+	}
+	return routerConfig
+}
+
+func hasRoute(routerConfig proxy.RouterConfig, route proxy.RouteConfig) bool {
+	for _, existing := range routerConfig.Routes {
+		if reflect.DeepEqual(existing, route) {
+			return true
+		}
+	}
+	return false
+}
+
+// getConfigChecksum returns a checksum of the given proxy configuration
+func getConfigChecksum(configMap *corev1.ConfigMap) string {
+	return fmt.Sprintf("%x", sha256.Sum256(configMap.BinaryData[configFile]))
+}
+
+// newDaemonSet returns the node proxy DaemonSet, installing the plugins for all registered drivers
+// The pod template is annotated with a checksum of the configuration, so the proxies are restarted to load new routes.
+func (r *NodeProxyReconciler) newDaemonSet(ctx context.Context, name types.NamespacedName, proxyConfig controllerconfig.ProxyConfig, configMap *corev1.ConfigMap) (*appsv1.DaemonSet, error) {
+	plugins, err := getAllDriverPlugins(ctx, r.client)
+	if err != nil {
+		return nil, err
+	}
+
+	var initContainers []corev1.Container
+	for _, plugin := range plugins {
+		initContainers = append(initContainers, newDriverPluginContainer(plugin))
+	}
+
+	labels := map[string]string{
+		nodeProxyLabel: name.Name,
+	}
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: name.Namespace,
+			Name:      name.Name,
+			Labels:    labels,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+					Annotations: map[string]string{
+						proxyRuntimeVersionAnnotation:     proxyConfig.RuntimeVersion,
+						nodeProxyConfigChecksumAnnotation: getConfigChecksum(configMap),
+					},
+				},
+				Spec: corev1.PodSpec{
+					InitContainers: initContainers,
+					Containers: []corev1.Container{
+						{
+							Name:            proxyContainerName,
+							Image:           proxyConfig.Image,
+							ImagePullPolicy: proxyConfig.ImagePullPolicy,
+							Args: []string{
+								"--config",
+								fmt.Sprintf("/etc/atomix/%s", configFile),
+								"--plugins",
+								pluginsPath,
+							},
+							Env: []corev1.EnvVar{
+								{
+									Name: podNamespaceEnv,
+									ValueFrom: &corev1.EnvVarSource{
+										FieldRef: &corev1.ObjectFieldSelector{
+											FieldPath: "metadata.namespace",
+										},
+									},
+								},
+								{
+									Name: podNameEnv,
+									ValueFrom: &corev1.EnvVarSource{
+										FieldRef: &corev1.ObjectFieldSelector{
+											FieldPath: "metadata.name",
+										},
+									},
+								},
+								{
+									Name: nodeIDEnv,
+									ValueFrom: &corev1.EnvVarSource{
+										FieldRef: &corev1.ObjectFieldSelector{
+											FieldPath: "spec.nodeName",
+										},
+									},
+								},
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "runtime",
+									ContainerPort: defaultRuntimePort,
+									HostPort:      defaultRuntimePort,
+								},
+								{
+									Name:          "control",
+									ContainerPort: defaultProxyPort,
+								},
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "config",
+									ReadOnly:  true,
+									MountPath: "/etc/atomix",
+								},
+								{
+									Name:      pluginsVolumeName,
+									ReadOnly:  true,
+									MountPath: pluginsPath,
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "config",
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: configMap.Name,
+									},
+								},
+							},
+						},
+						{
+							Name: pluginsVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+var _ reconcile.Reconciler = &NodeProxyReconciler{}
+
+// injectNodeProxyEnv adds the address of the node's shared proxy and the pod's identity to the pod's containers
+func injectNodeProxyEnv(pod *corev1.Pod) {
+	env := []corev1.EnvVar{
+		{
+			Name: nodeProxyHostEnv,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "status.hostIP",
+				},
+			},
+		},
+		{
+			Name:  nodeProxyPortEnv,
+			Value: strconv.Itoa(defaultRuntimePort),
+		},
+		{
+			Name: nodeProxyPodIDEnv,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "metadata.uid",
+				},
+			},
+		},
+	}
+	for i, container := range pod.Spec.Containers {
+		for _, envVar := range env {
+			if !hasEnv(container, envVar.Name) {
+				container.Env = append(container.Env, envVar)
+			}
+		}
+		pod.Spec.Containers[i] = container
+	}
+}
+
+func hasEnv(container corev1.Container, name string) bool {
+	for _, envVar := range container.Env {
+		if envVar.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// getNodeProxyName returns the name of the node proxy DaemonSet
+func getNodeProxyName() types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: k8s.GetNamespace(),
+		Name:      fmt.Sprintf("%s-proxy", k8s.GetName()),
+	}
+}
+
+// isNodeProxyPod returns whether the given pod uses the proxy shared by the pods on its node
+func isNodeProxyPod(pod *corev1.Pod) bool {
+	return pod.Annotations[proxyModeAnnotation] == string(controllerconfig.NodeProxyMode)
+}
+
+// newNodeProxyCache returns a cache of the node proxy pods in the controller's namespace, indexed by node
+func newNodeProxyCache(mgr manager.Manager) (cache.Cache, error) {
+	selector, err := labels.NewRequirement(nodeProxyLabel, selection.Exists, nil)
+	if err != nil {
+		return nil, err
+	}
+	nodeProxies, err := cache.New(mgr.GetConfig(), cache.Options{
+		Scheme:    mgr.GetScheme(),
+		Mapper:    mgr.GetRESTMapper(),
+		Namespace: k8s.GetNamespace(),
+		SelectorsByObject: cache.SelectorsByObject{
+			&corev1.Pod{}: {
+				Label: labels.NewSelector().Add(*selector),
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := nodeProxies.IndexField(context.Background(), &corev1.Pod{}, podNodeIndex, getPodNodeIndexValues); err != nil {
+		return nil, err
+	}
+	if err := mgr.Add(nodeProxies); err != nil {
+		return nil, err
+	}
+	return nodeProxies, nil
+}
+
+func getPodNodeIndexValues(object client.Object) []string {
+	nodeName := object.(*corev1.Pod).Spec.NodeName
+	if nodeName == "" {
+		return nil
+	}
+	return []string{nodeName}
+}
+
+// getNodeProxyPodRequests returns requests for the Proxies of the pods sharing the node of the given node proxy pod
+func getNodeProxyPodRequests(c client.Client, object client.Object) []reconcile.Request {
+	nodeName := object.(*corev1.Pod).Spec.NodeName
+	if nodeName == "" {
+		return nil
+	}
+
+	podList := &corev1.PodList{}
+	if err := c.List(context.Background(), podList, client.MatchingFields{podNodeIndex: nodeName}); err != nil {
+		log.Error(err)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, pod := range podList.Items {
+		if !isNodeProxyPod(&pod) {
+			continue
+		}
+		proxies, err := getPodProxies(context.Background(), c, pod.Namespace, pod.Name)
+		if err != nil {
+			log.Error(err)
+			return nil
+		}
+		for _, proxy := range proxies {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: getNamespacedName(&proxy),
+			})
+		}
+	}
+	return requests
+}
+
+// getNodeProxyPod returns the running node proxy pod on the given node
+func getNodeProxyPod(ctx context.Context, nodeProxies client.Reader, nodeName string) (*corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	if err := nodeProxies.List(ctx, podList, client.MatchingFields{podNodeIndex: nodeName}); err != nil {
+		return nil, err
+	}
+	for _, pod := range podList.Items {
+		if pod.Status.Phase == corev1.PodRunning && pod.Status.PodIP != "" && pod.DeletionTimestamp == nil {
+			return &pod, nil
+		}
+	}
+	return nil, k8serrors.NewNotFound(corev1.Resource("pods"), fmt.Sprintf("%s-%s", getNodeProxyName().Name, nodeName))
+}
+
+// getNodeProxyPodByEndpoint returns the node proxy pod with the given control endpoint, if any
+func getNodeProxyPodByEndpoint(ctx context.Context, nodeProxies client.Reader, address string) (*corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	if err := nodeProxies.List(ctx, podList); err != nil {
+		return nil, err
+	}
+	for _, pod := range podList.Items {
+		if pod.Status.PodIP != "" && getPodEndpoint(&pod).address == address {
+			return &pod, nil
+		}
+	}
+	return nil, nil
+}
+
+// getNodeProxyEndpoint returns the control endpoint of the node proxy shared by the given pod
+// Stores connected in a node proxy are shared by all the pods on the node: the proxy does not isolate pods' bindings.
+func getNodeProxyEndpoint(pod *corev1.Pod, nodeProxyPod *corev1.Pod) *proxyEndpoint {
+	endpoint := getPodEndpoint(nodeProxyPod)
+	endpoint.object = pod
+	endpoint.shared = true
+	endpoint.nodeName = nodeProxyPod.Spec.NodeName
+	return endpoint
+}
+
+// isStoreShared returns whether any other Proxy connected to the given shared proxy has bound the store.
+// Stores are connected in a shared proxy by the first Proxy on the node binding them, and disconnected once
+// the last Proxy binding them on the node releases them. Only the Proxies of the pods on the proxy's node are
+// counted. Proxies sharing a node proxy are reconciled while holding the endpoint's lock, and their statuses
+// are read from the API server, so the count is never stale.
+func (r *ProxyReconciler) isStoreShared(ctx context.Context, endpoint *proxyEndpoint, proxy *atomixv1beta1.Proxy, storeNamespacedName types.NamespacedName) (bool, error) {
+	// If the node proxy is gone there's no store left to share
+	if !endpoint.shared || endpoint.nodeName == "" {
+		return false, nil
+	}
+
+	podList := &corev1.PodList{}
+	if err := r.client.List(ctx, podList, client.MatchingFields{podNodeIndex: endpoint.nodeName}); err != nil {
+		return false, err
+	}
+	for _, pod := range podList.Items {
+		if !isNodeProxyPod(&pod) {
+			continue
+		}
+		proxies, err := getPodProxies(ctx, r.client, pod.Namespace, pod.Name)
+		if err != nil {
+			return false, err
+		}
+		for _, other := range proxies {
+			if other.UID == proxy.UID {
+				continue
+			}
+			if err := r.reader.Get(ctx, getNamespacedName(&other), &other); err != nil {
+				if k8serrors.IsNotFound(err) {
+					continue
+				}
+				return false, err
+			}
+			if shared, err := r.isStoreBound(ctx, &other, endpoint.address, storeNamespacedName); err != nil {
+				return false, err
+			} else if shared {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// isStoreBound returns whether the given Proxy has bound the store in the proxy with the given address
+func (r *ProxyReconciler) isStoreBound(ctx context.Context, proxy *atomixv1beta1.Proxy, address string, storeNamespacedName types.NamespacedName) (bool, error) {
+	if proxy.DeletionTimestamp != nil || proxy.Status.Endpoint != address {
+		return false, nil
+	}
+	profile, err := getProfileSpec(ctx, r.client, proxy.Namespace, proxy.Profile)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, binding := range profile.Bindings {
+		if getStoreID(proxy.Namespace, binding.Store) != storeNamespacedName {
+			continue
+		}
+		for _, status := range proxy.Status.Bindings {
+			if status.Name == binding.Name && status.State == atomixv1beta1.BindingBound {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// reconcileNodeEndpoint returns the endpoint of the node proxy shared by the Proxy's pod
+// If the node proxy changed, the status is updated and a nil endpoint is returned.
+func (r *ProxyReconciler) reconcileNodeEndpoint(ctx context.Context, proxy *atomixv1beta1.Proxy, pod *corev1.Pod) (*proxyEndpoint, error) {
+	nodeProxyPod, err := getNodeProxyPod(ctx, r.nodeProxies, pod.Spec.NodeName)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return nil, err
+		}
+		log.Warnf("No proxy running on node '%s' for Proxy '%s'", pod.Spec.NodeName, getNamespacedName(proxy))
+		return nil, nil
+	}
+	endpoint := getNodeProxyEndpoint(pod, nodeProxyPod)
+	r.setTransport(endpoint, proxy.Namespace, nodeProxyPod)
+
+	// Reset the bindings if the node proxy has been replaced, since the new proxy is not connected
+	if proxy.Status.Endpoint != endpoint.address {
+		log.Infof("Proxy '%s' endpoint changed to node proxy '%s'", getNamespacedName(proxy), getNamespacedName(nodeProxyPod))
+		proxy.Status.Endpoint = endpoint.address
+		resetBindings(proxy)
+		return nil, r.setStatus(ctx, proxy)
+	}
+
+	// Add a finalizer to release the pod's bindings in the node proxy when the Proxy is deleted
+	if !hasFinalizer(proxy, nodeProxyFinalizer) {
+		addFinalizer(proxy, nodeProxyFinalizer)
+		return nil, r.client.Update(ctx, proxy)
+	}
+	return endpoint, nil
+}
+
+// endpointLocks serializes changes to the bindings of Proxies sharing a node proxy
+type endpointLocks struct {
+	locks map[string]*endpointLock
+	mu    sync.Mutex
+}
+
+type endpointLock struct {
+	sync.Mutex
+	refs int
+}
+
+// lock locks the given endpoint, returning a function to unlock it
+func (l *endpointLocks) lock(address string) func() {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*endpointLock)
+	}
+	lock, ok := l.locks[address]
+	if !ok {
+		lock = &endpointLock{}
+		l.locks[address] = lock
+	}
+	lock.refs++
+	l.mu.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+		l.mu.Lock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(l.locks, address)
+		}
+		l.mu.Unlock()
+	}
+}
+
+// releaseNodeBindings disconnects the Proxy's bound stores from its node proxy and removes the finalizer.
+// Stores that cannot be disconnected, e.g. because the node proxy is gone, are released anyway.
+func (r *ProxyReconciler) releaseNodeBindings(ctx context.Context, proxy *atomixv1beta1.Proxy) error {
+	log.Infof("Releasing node proxy bindings for Proxy '%s'", getNamespacedName(proxy))
+	if proxy.Status.Endpoint != "" {
+		unlock := r.endpointLocks.lock(proxy.Status.Endpoint)
+		defer unlock()
+
+		endpoint := &proxyEndpoint{
+			object:      proxy,
+			address:     proxy.Status.Endpoint,
+			credentials: insecure.NewCredentials(),
+			shared:      true,
+		}
+		if nodeProxyPod, err := getNodeProxyPodByEndpoint(ctx, r.nodeProxies, proxy.Status.Endpoint); err != nil {
+			return err
+		} else if nodeProxyPod != nil {
+			endpoint.nodeName = nodeProxyPod.Spec.NodeName
+			r.setTransport(endpoint, proxy.Namespace, nodeProxyPod)
+		}
+
+		profile, err := getProfileSpec(ctx, r.client, proxy.Namespace, proxy.Profile)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		if profile != nil {
+			for _, binding := range profile.Bindings {
+				for _, status := range proxy.Status.Bindings {
+					if status.Name == binding.Name && status.State == atomixv1beta1.BindingBound {
+						releaseCtx, cancel := context.WithTimeout(ctx, nodeProxyReleaseTimeout)
+						err := r.disconnect(releaseCtx, endpoint, proxy, getStoreID(proxy.Namespace, binding.Store), atomixv1beta1.DriverReference{})
+						cancel()
+						if err != nil {
+							log.Warnf("Failed releasing binding '%s' for Proxy '%s': %s", binding.Name, getNamespacedName(proxy), err)
+						}
+					}
+				}
+			}
+		}
+
+		// Reset the released bindings so they're no longer counted as bound on the node
+		resetBindings(proxy)
+		proxy.Status.Endpoint = ""
+		if err := r.setStatus(ctx, proxy); err != nil {
+			return err
+		}
+	}
+	removeFinalizer(proxy, nodeProxyFinalizer)
+	return r.client.Update(ctx, proxy)
+}
@@ -0,0 +1,347 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"context"
+	atomixv1beta1 "github.com/atomix/controller/pkg/apis/atomix/v1beta1"
+	controllerconfig "github.com/atomix/controller/pkg/controller/config"
+	"github.com/atomix/proxy/pkg/proxy"
+	"gopkg.in/yaml.v3"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"path/filepath"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"testing"
+)
+
+func newTestBinding(name string, store corev1.ObjectReference, names ...string) atomixv1beta1.ProfileBinding {
+	return atomixv1beta1.ProfileBinding{
+		Name:  name,
+		Store: store,
+		Primitives: []atomixv1beta1.PrimitiveBindingRule{
+			{
+				Kinds: []string{"Map"},
+				Names: names,
+			},
+		},
+	}
+}
+
+func newTestRoute(namespace, name string, names ...string) proxy.RouteConfig {
+	return proxy.RouteConfig{
+		Store: proxy.StoreID{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Rules: []proxy.RuleConfig{
+			{
+				Kinds: []string{"Map"},
+				Names: names,
+			},
+		},
+	}
+}
+
+func TestNewNodeRouterConfig(t *testing.T) {
+	clusterStore := corev1.ObjectReference{Kind: clusterStoreKind, Name: "shared"}
+	tests := []struct {
+		name     string
+		profiles []nodeProfile
+		want     []proxy.RouteConfig
+	}{
+		{
+			name: "no profiles",
+		},
+		{
+			name: "profiles ordered by namespace and name",
+			profiles: []nodeProfile{
+				{
+					namespace: "b",
+					ref:       atomixv1beta1.ProfileReference{Kind: profileKind, Name: "foo"},
+					spec: atomixv1beta1.ProfileSpec{
+						Bindings: []atomixv1beta1.ProfileBinding{newTestBinding("local", corev1.ObjectReference{Name: "local"}, "b-*")},
+					},
+				},
+				{
+					namespace: "a",
+					ref:       atomixv1beta1.ProfileReference{Kind: profileKind, Name: "foo"},
+					spec: atomixv1beta1.ProfileSpec{
+						Bindings: []atomixv1beta1.ProfileBinding{newTestBinding("local", corev1.ObjectReference{Name: "local"}, "a-*")},
+					},
+				},
+			},
+			want: []proxy.RouteConfig{
+				newTestRoute("a", "local", "a-*"),
+				newTestRoute("b", "local", "b-*"),
+			},
+		},
+		{
+			name: "duplicate routes",
+			profiles: []nodeProfile{
+				{
+					namespace: "a",
+					ref:       atomixv1beta1.ProfileReference{Kind: clusterProfileKind, Name: "cluster"},
+					spec: atomixv1beta1.ProfileSpec{
+						Bindings: []atomixv1beta1.ProfileBinding{newTestBinding("shared", clusterStore, "*")},
+					},
+				},
+				{
+					namespace: "b",
+					ref:       atomixv1beta1.ProfileReference{Kind: clusterProfileKind, Name: "cluster"},
+					spec: atomixv1beta1.ProfileSpec{
+						Bindings: []atomixv1beta1.ProfileBinding{newTestBinding("shared", clusterStore, "*")},
+					},
+				},
+			},
+			want: []proxy.RouteConfig{
+				newTestRoute("", "shared", "*"),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := newNodeRouterConfig(test.profiles)
+			if !reflect.DeepEqual(got.Routes, test.want) {
+				t.Errorf("newNodeRouterConfig() = %+v, want %+v", got.Routes, test.want)
+			}
+		})
+	}
+}
+
+// TestNodeProxyRoutes checks the routes of the profiles used by pods in node mode are delivered to the node proxies
+func TestNodeProxyRoutes(t *testing.T) {
+	t.Setenv("CONTROLLER_NAMESPACE", "atomix")
+	t.Setenv("CONTROLLER_NAME", "atomix-controller")
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := atomixv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	profile := &atomixv1beta1.Profile{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "app",
+			Name:      "profile",
+		},
+		Spec: atomixv1beta1.ProfileSpec{
+			Bindings: []atomixv1beta1.ProfileBinding{newTestBinding("local", corev1.ObjectReference{Name: "local"}, "*")},
+		},
+	}
+	sidecarPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "app",
+			Name:      "sidecar",
+			Labels:    map[string]string{proxyInjectedLabel: "true"},
+			Annotations: map[string]string{
+				proxyProfileAnnotation: "other",
+			},
+		},
+	}
+	nodePod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "app",
+			Name:      "node",
+			Labels:    map[string]string{proxyInjectedLabel: "true"},
+			Annotations: map[string]string{
+				proxyProfileAnnotation: profile.Name,
+				proxyModeAnnotation:    string(controllerconfig.NodeProxyMode),
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(profile, sidecarPod, nodePod).Build()
+
+	controllerConfig, err := controllerconfig.NewWatcher(filepath.Join(t.TempDir(), "controller.yaml"), func(config *controllerconfig.Config) {
+		config.Proxy.Mode = controllerconfig.NodeProxyMode
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reconciler := &NodeProxyReconciler{
+		client:           c,
+		reader:           c,
+		scheme:           scheme,
+		controllerConfig: controllerConfig,
+	}
+	request := reconcile.Request{NamespacedName: getNodeProxyName()}
+
+	getRoutes := func() ([]proxy.RouteConfig, string) {
+		t.Helper()
+		if _, err := reconciler.Reconcile(context.TODO(), request); err != nil {
+			t.Fatal(err)
+		}
+
+		daemonSet := &appsv1.DaemonSet{}
+		if err := c.Get(context.TODO(), request.NamespacedName, daemonSet); err != nil {
+			t.Fatal(err)
+		}
+		template := daemonSet.Spec.Template
+		container := template.Spec.Containers[0]
+		if !reflect.DeepEqual(container.Args[:2], []string{"--config", "/etc/atomix/config.yaml"}) {
+			t.Fatalf("proxy args = %v, want --config", container.Args)
+		}
+		var configMapName string
+		for _, volume := range template.Spec.Volumes {
+			if volume.ConfigMap != nil {
+				configMapName = volume.ConfigMap.Name
+			}
+		}
+
+		configMap := &corev1.ConfigMap{}
+		if err := c.Get(context.TODO(), client.ObjectKey{Namespace: daemonSet.Namespace, Name: configMapName}, configMap); err != nil {
+			t.Fatal(err)
+		}
+		if !isOwnedBy(configMap, daemonSet) {
+			t.Errorf("ConfigMap '%s' is not owned by the DaemonSet", configMapName)
+		}
+		if checksum := template.Annotations[nodeProxyConfigChecksumAnnotation]; checksum != getConfigChecksum(configMap) {
+			t.Errorf("checksum = %s, want %s", checksum, getConfigChecksum(configMap))
+		}
+
+		var routerConfig proxy.RouterConfig
+		if err := yaml.Unmarshal(configMap.BinaryData[configFile], &routerConfig); err != nil {
+			t.Fatal(err)
+		}
+		return routerConfig.Routes, template.Annotations[nodeProxyConfigChecksumAnnotation]
+	}
+
+	// The node proxies are configured with the routes of the node-mode pod's profile only
+	routes, checksum := getRoutes()
+	assertRoutesEqual(t, routes, []proxy.RouteConfig{newTestRoute("app", "local", "*")})
+
+	// Changes to the profile are delivered to the node proxies, restarting them
+	profile.Spec.Bindings = append(profile.Spec.Bindings, newTestBinding("remote", corev1.ObjectReference{Namespace: "stores", Name: "remote"}, "remote-*"))
+	if err := c.Update(context.TODO(), profile); err != nil {
+		t.Fatal(err)
+	}
+	routes, updatedChecksum := getRoutes()
+	assertRoutesEqual(t, routes, []proxy.RouteConfig{
+		newTestRoute("app", "local", "*"),
+		newTestRoute("stores", "remote", "remote-*"),
+	})
+	if updatedChecksum == checksum {
+		t.Error("DaemonSet was not updated with the new configuration checksum")
+	}
+}
+
+// assertRoutesEqual compares routes by their serialized form, since empty lists are decoded as empty rather than nil
+func assertRoutesEqual(t *testing.T, got, want []proxy.RouteConfig) {
+	t.Helper()
+	gotBytes, err := yaml.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantBytes, err := yaml.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(gotBytes) != string(wantBytes) {
+		t.Errorf("routes = %s, want %s", gotBytes, wantBytes)
+	}
+}
+
+func TestIsStoreBound(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := atomixv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	profile := &atomixv1beta1.Profile{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "app",
+			Name:      "profile",
+		},
+		Spec: atomixv1beta1.ProfileSpec{
+			Bindings: []atomixv1beta1.ProfileBinding{
+				newTestBinding("local", corev1.ObjectReference{Name: "local"}, "*"),
+				newTestBinding("remote", corev1.ObjectReference{Namespace: "stores", Name: "remote"}, "*"),
+			},
+		},
+	}
+	reconciler := &ProxyReconciler{
+		client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(profile).Build(),
+	}
+
+	const address = "10.0.0.1:5679"
+	now := metav1.Now()
+	tests := []struct {
+		name   string
+		update func(*atomixv1beta1.Proxy)
+		store  types.NamespacedName
+		want   bool
+	}{
+		{
+			name:  "bound",
+			store: types.NamespacedName{Namespace: "app", Name: "local"},
+			want:  true,
+		},
+		{
+			name:  "other store bound",
+			store: types.NamespacedName{Namespace: "stores", Name: "other"},
+		},
+		{
+			name:  "binding not bound",
+			store: types.NamespacedName{Namespace: "stores", Name: "remote"},
+		},
+		{
+			name: "other endpoint",
+			update: func(proxy *atomixv1beta1.Proxy) {
+				proxy.Status.Endpoint = "10.0.0.2:5679"
+			},
+			store: types.NamespacedName{Namespace: "app", Name: "local"},
+		},
+		{
+			name: "deleted",
+			update: func(proxy *atomixv1beta1.Proxy) {
+				proxy.DeletionTimestamp = &now
+			},
+			store: types.NamespacedName{Namespace: "app", Name: "local"},
+		},
+		{
+			name: "profile not found",
+			update: func(proxy *atomixv1beta1.Proxy) {
+				proxy.Profile.Name = "unknown"
+			},
+			store: types.NamespacedName{Namespace: "app", Name: "local"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			proxy := &atomixv1beta1.Proxy{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "app",
+					Name:      "proxy",
+				},
+				Profile: atomixv1beta1.ProfileReference{Name: profile.Name},
+				Status: atomixv1beta1.ProxyStatus{
+					Endpoint: address,
+					Bindings: []atomixv1beta1.BindingStatus{
+						{Name: "local", State: atomixv1beta1.BindingBound},
+						{Name: "remote", State: atomixv1beta1.BindingUnbound},
+					},
+				},
+			}
+			if test.update != nil {
+				test.update(proxy)
+			}
+			got, err := reconciler.isStoreBound(context.TODO(), proxy, address, test.store)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("isStoreBound() = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
@@ -8,13 +8,15 @@ import (
 	"context"
 	"fmt"
 	atomixv1beta1 "github.com/atomix/controller/pkg/apis/atomix/v1beta1"
+	controllerconfig "github.com/atomix/controller/pkg/controller/config"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
-	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -22,12 +24,17 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
-	"time"
 )
 
 const atomixReadyCondition = "AtomixReady"
 
-func addPodController(mgr manager.Manager) error {
+const podProfileIndex = "proxy.atomix.io/profile"
+
+const podListLimit = 500
+
+func addPodController(mgr manager.Manager, controllerConfig *controllerconfig.Watcher) error {
+	controllerOptions := controllerConfig.Get().GetController("pod-controller")
+
 	// Create a new controller
 	c, err := controller.New("pod-controller", mgr, controller.Options{
 		Reconciler: &PodReconciler{
@@ -35,7 +42,19 @@ func addPodController(mgr manager.Manager) error {
 			scheme: mgr.GetScheme(),
 			config: mgr.GetConfig(),
 		},
-		RateLimiter: workqueue.NewItemExponentialFailureRateLimiter(time.Millisecond*10, time.Second*5),
+		MaxConcurrentReconciles: controllerOptions.MaxConcurrentReconciles,
+		RateLimiter:             newRateLimiter(controllerOptions.RateLimiter),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Index injected pods by profile
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Pod{}, podProfileIndex, func(object client.Object) []string {
+		if profile, ok := getPodProfile(object.(*corev1.Pod)); ok {
+			return []string{getPodProfileIndexValue(profile.Kind, profile.Name)}
+		}
+		return nil
 	})
 	if err != nil {
 		return err
@@ -48,33 +67,46 @@ func addPodController(mgr manager.Manager) error {
 	}
 
 	// Watch for changes to Proxies
-	err = c.Watch(&source.Kind{Type: &atomixv1beta1.Proxy{}}, &handler.EnqueueRequestForOwner{
-		OwnerType: &corev1.Pod{},
-	})
+	err = c.Watch(&source.Kind{Type: &atomixv1beta1.Proxy{}}, handler.EnqueueRequestsFromMapFunc(func(object client.Object) []reconcile.Request {
+		podName := object.(*atomixv1beta1.Proxy).Pod.Name
+		if podName == "" {
+			return nil
+		}
+		return []reconcile.Request{
+			{
+				NamespacedName: types.NamespacedName{
+					Namespace: object.GetNamespace(),
+					Name:      podName,
+				},
+			},
+		}
+	}))
 	if err != nil {
 		return err
 	}
 
 	// Watch for changes to Profiles
 	err = c.Watch(&source.Kind{Type: &atomixv1beta1.Profile{}}, handler.EnqueueRequestsFromMapFunc(func(object client.Object) []reconcile.Request {
-		podList := &corev1.PodList{}
-		if err := mgr.GetClient().List(context.Background(), podList, &client.ListOptions{Namespace: object.GetNamespace()}); err != nil {
-			log.Error(err)
-			return nil
-		}
+		return getProfilePodRequests(mgr.GetClient(), object.GetNamespace(), profileKind, object.GetName())
+	}))
+	if err != nil {
+		return err
+	}
 
-		var requests []reconcile.Request
-		for _, pod := range podList.Items {
-			if pod.Annotations[proxyProfileAnnotation] == object.GetName() {
-				requests = append(requests, reconcile.Request{
-					NamespacedName: types.NamespacedName{
-						Namespace: pod.Namespace,
-						Name:      pod.Name,
-					},
-				})
-			}
+	// Watch for changes to ClusterProfiles
+	err = c.Watch(&source.Kind{Type: &atomixv1beta1.ClusterProfile{}}, handler.EnqueueRequestsFromMapFunc(func(object client.Object) []reconcile.Request {
+		return getProfilePodRequests(mgr.GetClient(), "", clusterProfileKind, object.GetName())
+	}))
+	if err != nil {
+		return err
+	}
+
+	// Label pods injected by earlier versions of the controller so they're cached and reconciled
+	err = mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		if err := labelInjectedPods(ctx, mgr.GetAPIReader(), mgr.GetClient()); err != nil {
+			log.Errorf("Failed to label injected pods: %s", err)
 		}
-		return requests
+		return nil
 	}))
 	if err != nil {
 		return err
@@ -82,6 +114,71 @@ func addPodController(mgr manager.Manager) error {
 	return nil
 }
 
+// labelInjectedPods adds the injected label to pods injected before the label was added by the injector
+func labelInjectedPods(ctx context.Context, reader client.Reader, c client.Client) error {
+	requirement, err := labels.NewRequirement(proxyInjectedLabel, selection.DoesNotExist, nil)
+	if err != nil {
+		return err
+	}
+	listOpts := &client.ListOptions{
+		LabelSelector: labels.NewSelector().Add(*requirement),
+		Limit:         podListLimit,
+	}
+	for {
+		podList := &corev1.PodList{}
+		if err := reader.List(ctx, podList, listOpts); err != nil {
+			return err
+		}
+		for i := range podList.Items {
+			pod := &podList.Items[i]
+			if pod.Annotations[proxyInjectStatusAnnotation] != injectedStatus {
+				continue
+			}
+
+			patch := client.MergeFrom(pod.DeepCopy())
+			if pod.Labels == nil {
+				pod.Labels = make(map[string]string)
+			}
+			pod.Labels[proxyInjectedLabel] = "true"
+			log.Infof("Labeling injected Pod '%s'", getNamespacedName(pod))
+			if err := c.Patch(ctx, pod, patch); err != nil && !k8serrors.IsNotFound(err) {
+				return err
+			}
+		}
+		if podList.Continue == "" {
+			return nil
+		}
+		listOpts.Continue = podList.Continue
+	}
+}
+
+// getPodProfileIndexValue returns the profile index value for the given profile kind and name
+func getPodProfileIndexValue(kind string, name string) string {
+	return fmt.Sprintf("%s/%s", kind, name)
+}
+
+// getProfilePodRequests returns requests for the injected pods referencing the given profile
+func getProfilePodRequests(c client.Client, namespace string, kind string, name string) []reconcile.Request {
+	podList := &corev1.PodList{}
+	options := []client.ListOption{
+		client.InNamespace(namespace),
+		client.MatchingLabels{proxyInjectedLabel: "true"},
+		client.MatchingFields{podProfileIndex: getPodProfileIndexValue(kind, name)},
+	}
+	if err := c.List(context.Background(), podList, options...); err != nil {
+		log.Error(err)
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(podList.Items))
+	for _, pod := range podList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: getNamespacedName(&pod),
+		})
+	}
+	return requests
+}
+
 // PodReconciler is a Reconciler for Profiles
 type PodReconciler struct {
 	client client.Client
@@ -102,17 +199,12 @@ func (r *PodReconciler) Reconcile(ctx context.Context, request reconcile.Request
 		return reconcile.Result{}, err
 	}
 
-	profileName, ok := pod.Annotations[proxyProfileAnnotation]
+	profile, ok := getPodProfile(pod)
 	if !ok {
 		return reconcile.Result{}, nil
 	}
 
-	profileNamespacedName := types.NamespacedName{
-		Namespace: pod.Namespace,
-		Name:      profileName,
-	}
-	profile := &atomixv1beta1.Profile{}
-	if err := r.client.Get(ctx, profileNamespacedName, profile); err != nil {
+	if _, err := getProfileSpec(ctx, r.client, pod.Namespace, profile); err != nil {
 		if !k8serrors.IsNotFound(err) {
 			log.Error(err)
 			return reconcile.Result{}, err
@@ -120,12 +212,21 @@ func (r *PodReconciler) Reconcile(ctx context.Context, request reconcile.Request
 		return reconcile.Result{}, nil
 	}
 
+	// Use the Proxy referencing the pod if one exists, otherwise create one
+	proxies, err := getPodProxies(ctx, r.client, pod.Namespace, pod.Name)
+	if err != nil {
+		log.Error(err)
+		return reconcile.Result{}, err
+	}
+
 	proxyNamespacedName := types.NamespacedName{
 		Namespace: pod.Namespace,
 		Name:      pod.Name,
 	}
 	proxy := &atomixv1beta1.Proxy{}
-	if err := r.client.Get(ctx, proxyNamespacedName, proxy); err != nil {
+	if len(proxies) > 0 {
+		proxy = &proxies[0]
+	} else if err := r.client.Get(ctx, proxyNamespacedName, proxy); err != nil {
 		if !k8serrors.IsNotFound(err) {
 			log.Error(err)
 			return reconcile.Result{}, err
@@ -139,9 +240,7 @@ func (r *PodReconciler) Reconcile(ctx context.Context, request reconcile.Request
 			Pod: corev1.LocalObjectReference{
 				Name: pod.Name,
 			},
-			Profile: corev1.LocalObjectReference{
-				Name: profileName,
-			},
+			Profile: profile,
 		}
 
 		if err := controllerutil.SetOwnerReference(pod, proxy, r.scheme); err != nil {
@@ -167,7 +266,14 @@ func (r *PodReconciler) Reconcile(ctx context.Context, request reconcile.Request
 	}
 
 	for _, binding := range proxy.Status.Bindings {
-		if binding.State != atomixv1beta1.BindingBound {
+		if binding.State == atomixv1beta1.BindingDenied || binding.State == atomixv1beta1.BindingIncompatible {
+			if ok, err := r.setAtomixCondition(pod, corev1.ConditionFalse, fmt.Sprintf("Binding%s", binding.State), fmt.Sprintf("Cannot bind '%s': %s", binding.Name, binding.Message)); err != nil {
+				log.Error(err)
+				return reconcile.Result{}, err
+			} else if ok {
+				return reconcile.Result{}, nil
+			}
+		} else if binding.State != atomixv1beta1.BindingBound {
 			if ok, err := r.setAtomixCondition(pod, corev1.ConditionFalse, "Configuring", fmt.Sprintf("Configuring binding '%s'", binding.Name)); err != nil {
 				log.Error(err)
 				return reconcile.Result{}, err
@@ -198,6 +304,9 @@ func (r *PodReconciler) setAtomixCondition(pod *corev1.Pod, status corev1.Condit
 				types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}, status, reason, message)
 			if condition.Status != status {
 				condition.LastTransitionTime = metav1.Now()
+				if status == corev1.ConditionTrue {
+					podReadyDuration.Observe(condition.LastTransitionTime.Sub(pod.CreationTimestamp.Time).Seconds())
+				}
 			}
 			condition.Status = status
 			condition.Reason = reason
@@ -213,13 +322,17 @@ func (r *PodReconciler) setAtomixCondition(pod *corev1.Pod, status corev1.Condit
 
 	log.Infof("Initializing Pod %s condition: status=%s, reason=%s, message=%s",
 		types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}, status, reason, message)
-	pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{
+	condition := corev1.PodCondition{
 		Type:               atomixReadyCondition,
 		Status:             status,
 		LastTransitionTime: metav1.Now(),
 		Reason:             reason,
 		Message:            message,
-	})
+	}
+	if status == corev1.ConditionTrue {
+		podReadyDuration.Observe(condition.LastTransitionTime.Sub(pod.CreationTimestamp.Time).Seconds())
+	}
+	pod.Status.Conditions = append(pod.Status.Conditions, condition)
 	if err := r.client.Status().Update(context.TODO(), pod); err != nil {
 		return false, err
 	}
@@ -5,16 +5,22 @@
 package v1beta1
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	atomixv1beta1 "github.com/atomix/controller/pkg/apis/atomix/v1beta1"
+	controllerconfig "github.com/atomix/controller/pkg/controller/config"
 	"github.com/atomix/proxy/pkg/proxy"
 	"gopkg.in/yaml.v3"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/rest"
-	"k8s.io/client-go/util/workqueue"
+	"net/http"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -22,20 +28,40 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
-	"time"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 const configFile = "config.yaml"
 
-func addProfileController(mgr manager.Manager) error {
+const profileValidatePath = "/validate-profile"
+
+const (
+	profileKind        = "Profile"
+	clusterProfileKind = "ClusterProfile"
+)
+
+func addProfileController(mgr manager.Manager, controllerConfig *controllerconfig.Watcher) error {
+	controllerOptions := controllerConfig.Get().GetController("profile-controller")
+
+	mgr.GetWebhookServer().Register(profileValidatePath, &webhook.Admission{
+		Handler: &ProfileValidator{
+			client: mgr.GetClient(),
+			scheme: mgr.GetScheme(),
+		},
+	})
+
 	// Create a new controller
 	c, err := controller.New("profile-controller", mgr, controller.Options{
 		Reconciler: &ProfileReconciler{
 			client: mgr.GetClient(),
 			scheme: mgr.GetScheme(),
 			config: mgr.GetConfig(),
+
+			controllerConfig: controllerConfig,
 		},
-		RateLimiter: workqueue.NewItemExponentialFailureRateLimiter(time.Millisecond*10, time.Second*5),
+		MaxConcurrentReconciles: controllerOptions.MaxConcurrentReconciles,
+		RateLimiter:             newRateLimiter(controllerOptions.RateLimiter),
 	})
 	if err != nil {
 		return err
@@ -54,14 +80,42 @@ func addProfileController(mgr manager.Manager) error {
 	if err != nil {
 		return err
 	}
+
+	// Watch for changes to NetworkPolicies
+	err = c.Watch(&source.Kind{Type: &networkingv1.NetworkPolicy{}}, &handler.EnqueueRequestForOwner{
+		OwnerType:    &atomixv1beta1.Profile{},
+		IsController: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Reconcile all Profiles when the configuration changes to enable or disable their NetworkPolicies
+	err = c.Watch(newConfigSource(controllerConfig, func(ctx context.Context) ([]reconcile.Request, error) {
+		profileList := &atomixv1beta1.ProfileList{}
+		if err := mgr.GetClient().List(ctx, profileList); err != nil {
+			return nil, err
+		}
+		var requests []reconcile.Request
+		for _, profile := range profileList.Items {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: getNamespacedName(&profile),
+			})
+		}
+		return requests, nil
+	}), &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
 // ProfileReconciler is a Reconciler for Profiles
 type ProfileReconciler struct {
-	client client.Client
-	scheme *runtime.Scheme
-	config *rest.Config
+	client           client.Client
+	scheme           *runtime.Scheme
+	config           *rest.Config
+	controllerConfig *controllerconfig.Watcher
 }
 
 // Reconcile reconciles Profile resources
@@ -77,61 +131,207 @@ func (r *ProfileReconciler) Reconcile(ctx context.Context, request reconcile.Req
 		return reconcile.Result{}, err
 	}
 
+	ref := atomixv1beta1.ProfileReference{
+		Kind: profileKind,
+		Name: profile.Name,
+	}
+	if err := reconcileProfileConfigMap(ctx, r.client, r.scheme, profile, profile.Namespace, ref, profile.Spec); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	networkPolicyConfig := r.controllerConfig.Get().Proxy.NetworkPolicy
+	if err := reconcileProfileNetworkPolicy(ctx, r.client, r.scheme, profile, profile.Namespace, ref, networkPolicyConfig); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// ProfileValidator is a validating webhook that checks Profile bindings are permitted to reference their stores
+type ProfileValidator struct {
+	client  client.Client
+	scheme  *runtime.Scheme
+	decoder *admission.Decoder
+}
+
+// InjectDecoder :
+func (v *ProfileValidator) InjectDecoder(decoder *admission.Decoder) error {
+	v.decoder = decoder
+	return nil
+}
+
+// Handle :
+func (v *ProfileValidator) Handle(ctx context.Context, request admission.Request) admission.Response {
+	log.Infof("Received admission request for Profile '%s'", request.UID)
+
+	// Decode the profile
+	profile := &atomixv1beta1.Profile{}
+	if err := v.decoder.Decode(request, profile); err != nil {
+		log.Errorf("Could not decode Profile '%s'", request.UID, err)
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	for _, binding := range profile.Spec.Bindings {
+		storeID := getStoreID(profile.Namespace, binding.Store)
+		if ok, err := isStoreAccessGranted(ctx, v.client, profile.Namespace, binding.Store.Kind, storeID); err != nil {
+			log.Errorf("Profile validation failed for Profile '%s'", request.UID, err)
+			return admission.Errored(http.StatusInternalServerError, err)
+		} else if !ok {
+			log.Warnf("Binding '%s' denied for Profile '%s'", binding.Name, request.UID)
+			return admission.Denied(fmt.Sprintf("binding '%s' denied: %s", binding.Name, getStoreAccessDeniedMessage(profile.Namespace, storeID)))
+		}
+	}
+	return admission.Allowed("")
+}
+
+var _ admission.Handler = &ProfileValidator{}
+
+// getPodProfile returns a reference to the profile named by the pod's annotations
+func getPodProfile(pod *corev1.Pod) (atomixv1beta1.ProfileReference, bool) {
+	profileName, ok := pod.Annotations[proxyProfileAnnotation]
+	if !ok {
+		return atomixv1beta1.ProfileReference{}, false
+	}
+	kind, ok := pod.Annotations[proxyProfileKindAnnotation]
+	if !ok {
+		kind = profileKind
+	}
+	return atomixv1beta1.ProfileReference{
+		Kind: kind,
+		Name: profileName,
+	}, true
+}
+
+// getProfile gets the Profile or ClusterProfile referenced from the given namespace along with its spec
+func getProfile(ctx context.Context, c client.Client, namespace string, ref atomixv1beta1.ProfileReference) (client.Object, *atomixv1beta1.ProfileSpec, error) {
+	switch ref.Kind {
+	case "", profileKind:
+		profile := &atomixv1beta1.Profile{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, profile); err != nil {
+			return nil, nil, err
+		}
+		return profile, &profile.Spec, nil
+	case clusterProfileKind:
+		clusterProfile := &atomixv1beta1.ClusterProfile{}
+		if err := c.Get(ctx, types.NamespacedName{Name: ref.Name}, clusterProfile); err != nil {
+			return nil, nil, err
+		}
+		return clusterProfile, &clusterProfile.Spec, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown profile kind '%s'", ref.Kind)
+	}
+}
+
+// getProfileSpec gets the spec of the Profile or ClusterProfile referenced from the given namespace
+func getProfileSpec(ctx context.Context, c client.Client, namespace string, ref atomixv1beta1.ProfileReference) (*atomixv1beta1.ProfileSpec, error) {
+	_, spec, err := getProfile(ctx, c, namespace, ref)
+	return spec, err
+}
+
+// getProfileConfigMapName returns the name of the ConfigMap holding the proxy configuration for the given profile
+// Names are prefixed by the profile's kind, so Profiles and ClusterProfiles never share a ConfigMap.
+func getProfileConfigMapName(ref atomixv1beta1.ProfileReference) string {
+	if ref.Kind == clusterProfileKind {
+		return truncateName(fmt.Sprintf("cluster-profile.%s", ref.Name), validation.DNS1123SubdomainMaxLength)
+	}
+	return truncateName(fmt.Sprintf("profile.%s", ref.Name), validation.DNS1123SubdomainMaxLength)
+}
+
+// reconcileProfileConfigMap creates or updates the proxy configuration ConfigMap for the given profile in the given namespace
+func reconcileProfileConfigMap(ctx context.Context, c client.Client, scheme *runtime.Scheme, owner client.Object, namespace string, ref atomixv1beta1.ProfileReference, spec atomixv1beta1.ProfileSpec) error {
+	configMapName := types.NamespacedName{
+		Namespace: namespace,
+		Name:      getProfileConfigMapName(ref),
+	}
+	desired, err := newProfileConfigMap(configMapName.Namespace, configMapName.Name, spec)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	return reconcileConfigMap(ctx, c, c, scheme, owner, desired)
+}
+
+// reconcileConfigMap creates the given ConfigMap owned by the given owner, or updates its configuration if it exists
+// Existing ConfigMaps are read with the given reader, since they may be in namespaces that aren't cached.
+func reconcileConfigMap(ctx context.Context, c client.Client, reader client.Reader, scheme *runtime.Scheme, owner client.Object, desired *corev1.ConfigMap) error {
+	configMapName := getNamespacedName(desired)
 	configMap := &corev1.ConfigMap{}
-	if err := r.client.Get(ctx, getNamespacedName(profile), configMap); err != nil {
+	if err := reader.Get(ctx, configMapName, configMap); err != nil {
 		if !k8serrors.IsNotFound(err) {
 			log.Error(err)
-			return reconcile.Result{}, err
+			return err
 		}
 
-		var routerConfig proxy.RouterConfig
-		for _, binding := range profile.Spec.Bindings {
-			var route proxy.RouteConfig
-			storeNamespace := binding.Store.Namespace
-			if storeNamespace == "" {
-				storeNamespace = profile.Namespace
-			}
-			route.Store = proxy.StoreID{
-				Namespace: storeNamespace,
-				Name:      binding.Store.Name,
-			}
-			for _, primitive := range binding.Primitives {
-				rule := proxy.RuleConfig{
-					Kinds:       primitive.Kinds,
-					APIVersions: primitive.APIVersions,
-					Names:       primitive.Names,
-					Tags:        primitive.Tags,
-				}
-				route.Rules = append(route.Rules, rule)
-			}
-			routerConfig.Routes = append(routerConfig.Routes, route)
+		if err := controllerutil.SetOwnerReference(owner, desired, scheme); err != nil {
+			log.Error(err)
+			return err
 		}
 
-		configBytes, err := yaml.Marshal(routerConfig)
-		if err != nil {
+		log.Infof("Creating ConfigMap '%s'", configMapName)
+		if err := c.Create(ctx, desired); err != nil && !k8serrors.IsAlreadyExists(err) {
 			log.Error(err)
-			return reconcile.Result{}, err
+			return err
 		}
+		return nil
+	}
 
-		configMap = &corev1.ConfigMap{
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: profile.Namespace,
-				Name:      profile.Name,
-			},
-			BinaryData: map[string][]byte{
-				configFile: configBytes,
-			},
-		}
+	// Never mount the configuration of another resource into the proxies
+	if !isOwnedBy(configMap, owner) {
+		err := fmt.Errorf("ConfigMap '%s' is not owned by '%s'", configMapName, getNamespacedName(owner))
+		log.Error(err)
+		return err
+	}
 
-		if err := controllerutil.SetOwnerReference(profile, configMap, r.scheme); err != nil {
+	if !bytes.Equal(configMap.BinaryData[configFile], desired.BinaryData[configFile]) {
+		log.Infof("Updating ConfigMap '%s'", configMapName)
+		configMap.BinaryData = desired.BinaryData
+		if err := c.Update(ctx, configMap); err != nil {
 			log.Error(err)
-			return reconcile.Result{}, err
+			return err
 		}
+	}
+	return nil
+}
 
-		if err := r.client.Create(ctx, configMap); err != nil {
-			log.Error(err)
-			return reconcile.Result{}, err
+// newProfileConfigMap creates a proxy configuration ConfigMap for the given profile spec
+func newProfileConfigMap(namespace, name string, spec atomixv1beta1.ProfileSpec) (*corev1.ConfigMap, error) {
+	configBytes, err := yaml.Marshal(newRouterConfig(namespace, spec))
+	if err != nil {
+		return nil, err
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		BinaryData: map[string][]byte{
+			configFile: configBytes,
+		},
+	}
+	return configMap, nil
+}
+
+// newRouterConfig returns the proxy router configuration for the bindings of the given profile spec
+func newRouterConfig(namespace string, spec atomixv1beta1.ProfileSpec) proxy.RouterConfig {
+	var routerConfig proxy.RouterConfig
+	for _, binding := range spec.Bindings {
+		var route proxy.RouteConfig
+		storeID := getStoreID(namespace, binding.Store)
+		route.Store = proxy.StoreID{
+			Namespace: storeID.Namespace,
+			Name:      storeID.Name,
 		}
+		for _, primitive := range binding.Primitives {
+			rule := proxy.RuleConfig{
+				Kinds:       primitive.Kinds,
+				APIVersions: primitive.APIVersions,
+				Names:       primitive.Names,
+				Tags:        primitive.Tags,
+			}
+			route.Rules = append(route.Rules, rule)
+		}
+		routerConfig.Routes = append(routerConfig.Routes, route)
 	}
-	return reconcile.Result{}, nil
+	return routerConfig
 }
@@ -6,25 +6,36 @@ package v1beta1
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	atomixv1beta1 "github.com/atomix/controller/pkg/apis/atomix/v1beta1"
+	controllerconfig "github.com/atomix/controller/pkg/controller/config"
+	"github.com/atomix/controller/pkg/controller/util/portforward"
+	"github.com/atomix/controller/pkg/controller/util/tracing"
 	proxyv1 "github.com/atomix/proxy/api/atomix/proxy/v1"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/json"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
-	"k8s.io/client-go/util/workqueue"
+	"net"
 	"net/http"
-	"os"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -41,59 +52,138 @@ const (
 )
 
 const (
-	proxyInjectPath             = "/inject-proxy"
-	proxyInjectAnnotation       = "proxy.atomix.io/inject"
-	proxyInjectStatusAnnotation = "proxy.atomix.io/status"
-	proxyProfileAnnotation      = "proxy.atomix.io/profile"
-	injectedStatus              = "injected"
-	proxyContainerName          = "atomix-proxy"
+	proxyInjectPath               = "/inject-proxy"
+	proxyInjectAnnotation         = "proxy.atomix.io/inject"
+	proxyInjectStatusAnnotation   = "proxy.atomix.io/status"
+	proxyProfileAnnotation        = "proxy.atomix.io/profile"
+	proxyProfileKindAnnotation    = "proxy.atomix.io/profile-kind"
+	proxyRuntimeVersionAnnotation = "proxy.atomix.io/runtime-version"
+	proxyInjectedLabel            = "proxy.atomix.io/injected"
+	proxyProfileLabel             = "proxy.atomix.io/profile"
+	proxyProfileKindLabel         = "proxy.atomix.io/profile-kind"
+	proxyModeAnnotation           = "proxy.atomix.io/mode"
+	injectedStatus                = "injected"
+	proxyContainerName            = "atomix-proxy"
 )
 
 const (
-	proxyImageEnv     = "PROXY_IMAGE"
-	defaultProxyImage = "atomix/proxy:latest"
+	defaultRuntimePort = 5678
+	defaultProxyPort   = 5679
 )
 
+// proxyTLSCAKey is the key of the CA certificate in a proxy's TLS Secret
+const proxyTLSCAKey = "ca.crt"
+
+const proxyPodIndex = "proxy.atomix.io/pod"
+
+// uncachedPodPollInterval is the interval at which Proxies poll for changes to pods that are not cached
+const uncachedPodPollInterval = 30 * time.Second
+
 const (
-	defaultProxyPort = 5679
+	pluginsVolumeName = "plugins"
+	pluginsPath       = "/var/lib/atomix/plugins"
 )
 
-func getProxyImage() string {
-	image := os.Getenv(proxyImageEnv)
-	if image != "" {
-		return image
+func addProxyController(mgr manager.Manager, controllerConfig *controllerconfig.Watcher) error {
+	controllerOptions := controllerConfig.Get().GetController("proxy-controller")
+
+	if err := metrics.Registry.Register(newBindingCollector(mgr.GetClient())); err != nil {
+		return err
 	}
-	return defaultProxyImage
-}
 
-func addProxyController(mgr manager.Manager) error {
 	mgr.GetWebhookServer().Register(proxyInjectPath, &webhook.Admission{
 		Handler: &ProxyInjector{
-			client: mgr.GetClient(),
-			scheme: mgr.GetScheme(),
+			client:           mgr.GetClient(),
+			scheme:           mgr.GetScheme(),
+			controllerConfig: controllerConfig,
 		},
 	})
 
+	nodeProxies, err := newNodeProxyCache(mgr)
+	if err != nil {
+		return err
+	}
+
+	portForward, err := portforward.NewDialer(mgr.GetConfig())
+	if err != nil {
+		return err
+	}
+
 	// Create a new controller
 	c, err := controller.New("proxy-controller", mgr, controller.Options{
 		Reconciler: &ProxyReconciler{
-			client: mgr.GetClient(),
-			scheme: mgr.GetScheme(),
-			config: mgr.GetConfig(),
-			events: mgr.GetEventRecorderFor("atomix"),
+			client:           mgr.GetClient(),
+			reader:           mgr.GetAPIReader(),
+			nodeProxies:      nodeProxies,
+			portForward:      portForward,
+			scheme:           mgr.GetScheme(),
+			config:           mgr.GetConfig(),
+			events:           mgr.GetEventRecorderFor("atomix"),
+			controllerConfig: controllerConfig,
 		},
-		RateLimiter: workqueue.NewItemExponentialFailureRateLimiter(time.Millisecond*10, time.Second*5),
+		MaxConcurrentReconciles: controllerOptions.MaxConcurrentReconciles,
+		RateLimiter:             newRateLimiter(controllerOptions.RateLimiter),
 	})
 	if err != nil {
 		return err
 	}
 
+	// Index proxies by pod
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &atomixv1beta1.Proxy{}, proxyPodIndex, func(object client.Object) []string {
+		podName := object.(*atomixv1beta1.Proxy).Pod.Name
+		if podName == "" {
+			return nil
+		}
+		return []string{podName}
+	})
+	if err != nil {
+		return err
+	}
+
+	// Index injected pods by node
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Pod{}, podNodeIndex, getPodNodeIndexValues)
+	if err != nil {
+		return err
+	}
+
 	// Watch for changes to Proxies
 	err = c.Watch(&source.Kind{Type: &atomixv1beta1.Proxy{}}, &handler.EnqueueRequestForObject{})
 	if err != nil {
 		return err
 	}
 
+	// Watch for changes to the Pods referenced by Proxies
+	err = c.Watch(&source.Kind{Type: &corev1.Pod{}}, handler.EnqueueRequestsFromMapFunc(func(object client.Object) []reconcile.Request {
+		proxies, err := getPodProxies(context.Background(), mgr.GetClient(), object.GetNamespace(), object.GetName())
+		if err != nil {
+			log.Error(err)
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(proxies))
+		for _, proxy := range proxies {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: getNamespacedName(&proxy),
+			})
+		}
+		return requests
+	}))
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the node proxies shared by the pods on each node
+	nodeProxyInformer, err := nodeProxies.GetInformer(context.Background(), &corev1.Pod{})
+	if err != nil {
+		return err
+	}
+	err = c.Watch(&source.Informer{Informer: nodeProxyInformer}, handler.EnqueueRequestsFromMapFunc(func(object client.Object) []reconcile.Request {
+		return getNodeProxyPodRequests(mgr.GetClient(), object)
+	}))
+	if err != nil {
+		return err
+	}
+
 	// Watch for changes to Profiles
 	err = c.Watch(&source.Kind{Type: &atomixv1beta1.Profile{}}, handler.EnqueueRequestsFromMapFunc(func(object client.Object) []reconcile.Request {
 		proxyList := &atomixv1beta1.ProxyList{}
@@ -104,7 +194,32 @@ func addProxyController(mgr manager.Manager) error {
 
 		var requests []reconcile.Request
 		for _, proxy := range proxyList.Items {
-			if proxy.Profile.Name == object.GetName() {
+			if (proxy.Profile.Kind == "" || proxy.Profile.Kind == profileKind) && proxy.Profile.Name == object.GetName() {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Namespace: proxy.Namespace,
+						Name:      proxy.Name,
+					},
+				})
+			}
+		}
+		return requests
+	}))
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterProfiles
+	err = c.Watch(&source.Kind{Type: &atomixv1beta1.ClusterProfile{}}, handler.EnqueueRequestsFromMapFunc(func(object client.Object) []reconcile.Request {
+		proxyList := &atomixv1beta1.ProxyList{}
+		if err := mgr.GetClient().List(context.Background(), proxyList); err != nil {
+			log.Error(err)
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, proxy := range proxyList.Items {
+			if proxy.Profile.Kind == clusterProfileKind && proxy.Profile.Name == object.GetName() {
 				requests = append(requests, reconcile.Request{
 					NamespacedName: types.NamespacedName{
 						Namespace: proxy.Namespace,
@@ -120,7 +235,33 @@ func addProxyController(mgr manager.Manager) error {
 	}
 
 	// Watch for changes to Stores
-	err = c.Watch(&source.Kind{Type: &atomixv1beta1.Store{}}, handler.EnqueueRequestsFromMapFunc(func(object client.Object) []reconcile.Request {
+	err = c.Watch(&source.Kind{Type: &atomixv1beta1.Store{}}, handler.EnqueueRequestsFromMapFunc(mapStoreToProxies(mgr)))
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterStores
+	err = c.Watch(&source.Kind{Type: &atomixv1beta1.ClusterStore{}}, handler.EnqueueRequestsFromMapFunc(mapStoreToProxies(mgr)))
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to StoreGrants
+	err = c.Watch(&source.Kind{Type: &atomixv1beta1.StoreGrant{}}, handler.EnqueueRequestsFromMapFunc(mapStoreToProxies(mgr)))
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to Drivers
+	err = c.Watch(&source.Kind{Type: &atomixv1beta1.Driver{}}, handler.EnqueueRequestsFromMapFunc(mapStoreToProxies(mgr)))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func mapStoreToProxies(mgr manager.Manager) handler.MapFunc {
+	return func(object client.Object) []reconcile.Request {
 		proxyList := &atomixv1beta1.ProxyList{}
 		if err := mgr.GetClient().List(context.Background(), proxyList); err != nil {
 			log.Error(err)
@@ -137,23 +278,42 @@ func addProxyController(mgr manager.Manager) error {
 			})
 		}
 		return requests
-	}))
-	if err != nil {
-		return err
 	}
-	return nil
+}
+
+// getPodProxies returns the Proxies referencing the named pod
+func getPodProxies(ctx context.Context, c client.Client, namespace string, name string) ([]atomixv1beta1.Proxy, error) {
+	proxyList := &atomixv1beta1.ProxyList{}
+	if err := c.List(ctx, proxyList, client.InNamespace(namespace), client.MatchingFields{proxyPodIndex: name}); err != nil {
+		return nil, err
+	}
+	return proxyList.Items, nil
 }
 
 // ProxyReconciler is a Reconciler for Proxies
 type ProxyReconciler struct {
-	client client.Client
-	scheme *runtime.Scheme
-	config *rest.Config
-	events record.EventRecorder
+	client           client.Client
+	reader           client.Reader
+	nodeProxies      client.Reader
+	portForward      *portforward.Dialer
+	scheme           *runtime.Scheme
+	config           *rest.Config
+	events           record.EventRecorder
+	controllerConfig *controllerconfig.Watcher
+	endpointLocks    endpointLocks
 }
 
 // Reconcile reconciles Proxy resources
 func (r *ProxyReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	ctx, span := tracer.Start(ctx, "ProxyReconciler.Reconcile",
+		trace.WithAttributes(attribute.String("proxy", request.NamespacedName.String())))
+	defer span.End()
+	result, err := r.reconcileProxy(ctx, request)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (r *ProxyReconciler) reconcileProxy(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	log.Infof("Reconciling Proxy '%s'", request.NamespacedName)
 	proxy := &atomixv1beta1.Proxy{}
 	err := r.client.Get(context.TODO(), request.NamespacedName, proxy)
@@ -165,44 +325,204 @@ func (r *ProxyReconciler) Reconcile(ctx context.Context, request reconcile.Reque
 		return reconcile.Result{}, err
 	}
 
-	podNamespacedName := types.NamespacedName{
-		Namespace: proxy.Namespace,
-		Name:      proxy.Name,
+	if proxy.DeletionTimestamp != nil {
+		if hasFinalizer(proxy, nodeProxyFinalizer) {
+			if err := r.releaseNodeBindings(ctx, proxy); err != nil {
+				log.Error(err)
+				return reconcile.Result{}, err
+			}
+		}
+		return reconcile.Result{}, nil
 	}
-	pod := &corev1.Pod{}
-	if err := r.client.Get(ctx, podNamespacedName, pod); err != nil {
-		if !k8serrors.IsNotFound(err) {
+
+	var endpoint *proxyEndpoint
+	var result reconcile.Result
+	if proxy.Endpoint != nil {
+		endpoint, err = r.reconcileExternalEndpoint(ctx, proxy)
+	} else {
+		endpoint, result, err = r.reconcilePodEndpoint(ctx, proxy)
+	}
+	if err != nil {
+		log.Error(err)
+		return reconcile.Result{}, err
+	} else if endpoint == nil {
+		return result, nil
+	}
+
+	// Serialize the reconciliation of Proxies sharing a proxy to count the Proxies binding each store
+	if endpoint.shared {
+		unlock := r.endpointLocks.lock(endpoint.address)
+		defer unlock()
+
+		// Re-read the Proxy from the API server, since its cached status may not reflect its last update
+		if err := r.reader.Get(ctx, request.NamespacedName, proxy); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return reconcile.Result{}, nil
+			}
 			log.Error(err)
 			return reconcile.Result{}, err
 		}
-		return reconcile.Result{}, nil
 	}
 
-	profileNamespacedName := types.NamespacedName{
-		Namespace: proxy.Namespace,
-		Name:      proxy.Profile.Name,
-	}
-	profile := &atomixv1beta1.Profile{}
-	if err := r.client.Get(ctx, profileNamespacedName, profile); err != nil {
+	profile, err := getProfileSpec(ctx, r.client, proxy.Namespace, proxy.Profile)
+	if err != nil {
 		if !k8serrors.IsNotFound(err) {
 			log.Error(err)
 			return reconcile.Result{}, err
 		}
-		return reconcile.Result{}, nil
+		return result, nil
 	}
 
-	for _, binding := range profile.Spec.Bindings {
-		if ok, err := r.reconcileBinding(ctx, pod, proxy, binding); err != nil {
+	for _, binding := range profile.Bindings {
+		if ok, err := r.reconcileBinding(ctx, endpoint, proxy, binding); err != nil {
 			return reconcile.Result{}, err
 		} else if ok {
-			return reconcile.Result{}, nil
+			return result, nil
+		}
+	}
+	return result, nil
+}
+
+// reconcilePodEndpoint returns the endpoint of the proxy injected into the Proxy's pod
+// If the pod's status changed, the status is updated and a nil endpoint is returned.
+// Only injected pods are cached and watched, so the returned result requeues the Proxy to poll
+// for changes to missing pods and pods into which no proxy was injected.
+func (r *ProxyReconciler) reconcilePodEndpoint(ctx context.Context, proxy *atomixv1beta1.Proxy) (*proxyEndpoint, reconcile.Result, error) {
+	podNamespacedName := types.NamespacedName{
+		Namespace: proxy.Namespace,
+		Name:      proxy.Pod.Name,
+	}
+	pod, err := r.getPod(ctx, podNamespacedName)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return nil, reconcile.Result{}, err
+		}
+
+		// Release the deleted pod's bindings in its node proxy before resetting them
+		result := reconcile.Result{RequeueAfter: uncachedPodPollInterval}
+		if hasFinalizer(proxy, nodeProxyFinalizer) {
+			return nil, result, r.releaseNodeBindings(ctx, proxy)
+		}
+		return nil, result, r.setPodNotFound(ctx, proxy)
+	}
+
+	var result reconcile.Result
+	if pod.Labels[proxyInjectedLabel] != "true" {
+		result.RequeueAfter = uncachedPodPollInterval
+	}
+
+	// Reset the bindings if the proxy's pod has been replaced, since the new pod's proxy is not connected
+	if proxy.Status.PodUID != pod.UID {
+		if hasFinalizer(proxy, nodeProxyFinalizer) {
+			return nil, result, r.releaseNodeBindings(ctx, proxy)
+		}
+		return nil, result, r.setPodFound(ctx, proxy, pod)
+	}
+
+	// Record the runtime version of the proxy injected into the pod
+	if runtimeVersion := pod.Annotations[proxyRuntimeVersionAnnotation]; proxy.Status.RuntimeVersion != runtimeVersion {
+		proxy.Status.RuntimeVersion = runtimeVersion
+		return nil, result, r.setStatus(ctx, proxy)
+	}
+
+	if isNodeProxyPod(pod) {
+		endpoint, err := r.reconcileNodeEndpoint(ctx, proxy, pod)
+		return endpoint, result, err
+	}
+
+	// Wait for the pod to be assigned an IP
+	if pod.Status.PodIP == "" {
+		return nil, result, nil
+	}
+	endpoint := getPodEndpoint(pod)
+	r.setTransport(endpoint, proxy.Namespace, pod)
+	return endpoint, result, nil
+}
+
+// reconcileExternalEndpoint returns the endpoint of a proxy running outside the cluster
+// If the endpoint changed, the status is updated and a nil endpoint is returned.
+func (r *ProxyReconciler) reconcileExternalEndpoint(ctx context.Context, proxy *atomixv1beta1.Proxy) (*proxyEndpoint, error) {
+	// Reset the bindings if the proxy's address has changed, since the proxy at the new address is not connected
+	if proxy.Status.Endpoint != proxy.Endpoint.Address {
+		log.Infof("Proxy '%s' endpoint changed to '%s'", getNamespacedName(proxy), proxy.Endpoint.Address)
+		proxy.Status.Endpoint = proxy.Endpoint.Address
+		proxy.Status.PodUID = ""
+		meta.RemoveStatusCondition(&proxy.Status.Conditions, atomixv1beta1.ProxyPodFound)
+		resetBindings(proxy)
+		return nil, r.setStatus(ctx, proxy)
+	}
+
+	// Record the runtime version configured for the external proxy
+	if proxy.Status.RuntimeVersion != proxy.Endpoint.RuntimeVersion {
+		proxy.Status.RuntimeVersion = proxy.Endpoint.RuntimeVersion
+		return nil, r.setStatus(ctx, proxy)
+	}
+	return r.getExternalEndpoint(ctx, proxy)
+}
+
+// getPod gets the named pod
+// Only injected pods are cached, so pods not found in the cache are read from the API server.
+func (r *ProxyReconciler) getPod(ctx context.Context, name types.NamespacedName) (*corev1.Pod, error) {
+	pod := &corev1.Pod{}
+	if err := r.client.Get(ctx, name, pod); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return nil, err
+		}
+		if err := r.reader.Get(ctx, name, pod); err != nil {
+			return nil, err
+		}
+	}
+	return pod, nil
+}
+
+// setPodFound records the proxy's pod in the proxy status, resetting the bindings of any previous pod
+func (r *ProxyReconciler) setPodFound(ctx context.Context, proxy *atomixv1beta1.Proxy, pod *corev1.Pod) error {
+	log.Infof("Found Pod '%s' for Proxy '%s'", getNamespacedName(pod), getNamespacedName(proxy))
+	proxy.Status.PodUID = pod.UID
+	proxy.Status.Endpoint = ""
+	resetBindings(proxy)
+	meta.SetStatusCondition(&proxy.Status.Conditions, metav1.Condition{
+		Type:    atomixv1beta1.ProxyPodFound,
+		Status:  metav1.ConditionTrue,
+		Reason:  "PodFound",
+		Message: fmt.Sprintf("Pod '%s' found", pod.Name),
+	})
+	return r.setStatus(ctx, proxy)
+}
+
+// setPodNotFound records in the proxy status that the proxy's pod does not exist
+func (r *ProxyReconciler) setPodNotFound(ctx context.Context, proxy *atomixv1beta1.Proxy) error {
+	message := fmt.Sprintf("Pod '%s' not found", proxy.Pod.Name)
+	if condition := meta.FindStatusCondition(proxy.Status.Conditions, atomixv1beta1.ProxyPodFound); condition != nil &&
+		condition.Status == metav1.ConditionFalse && condition.Message == message && proxy.Status.PodUID == "" && proxy.Status.Endpoint == "" {
+		return nil
+	}
+	log.Warnf("Pod '%s' not found for Proxy '%s'", proxy.Pod.Name, getNamespacedName(proxy))
+	proxy.Status.PodUID = ""
+	proxy.Status.Endpoint = ""
+	resetBindings(proxy)
+	meta.SetStatusCondition(&proxy.Status.Conditions, metav1.Condition{
+		Type:    atomixv1beta1.ProxyPodFound,
+		Status:  metav1.ConditionFalse,
+		Reason:  "PodNotFound",
+		Message: message,
+	})
+	return r.setStatus(ctx, proxy)
+}
+
+// resetBindings resets the state of the proxy's bound bindings, which are no longer connected
+func resetBindings(proxy *atomixv1beta1.Proxy) {
+	for i, status := range proxy.Status.Bindings {
+		if status.State == atomixv1beta1.BindingBound {
+			status.State = atomixv1beta1.BindingUnbound
+			status.Version = ""
+			proxy.Status.Bindings[i] = status
 		}
 	}
-	return reconcile.Result{}, nil
 }
 
 func (r *ProxyReconciler) setStatus(ctx context.Context, proxy *atomixv1beta1.Proxy) error {
-	ready := true
+	ready := proxy.Endpoint != nil || meta.IsStatusConditionTrue(proxy.Status.Conditions, atomixv1beta1.ProxyPodFound)
 	for _, status := range proxy.Status.Bindings {
 		if status.State != atomixv1beta1.BindingBound {
 			ready = false
@@ -213,17 +533,22 @@ func (r *ProxyReconciler) setStatus(ctx context.Context, proxy *atomixv1beta1.Pr
 	return r.client.Status().Update(ctx, proxy)
 }
 
-func (r *ProxyReconciler) reconcileBinding(ctx context.Context, pod *corev1.Pod, proxy *atomixv1beta1.Proxy, binding atomixv1beta1.ProfileBinding) (bool, error) {
-	storeNamespace := binding.Store.Namespace
-	if storeNamespace == "" {
-		storeNamespace = proxy.Namespace
-	}
-	storeNamespacedName := types.NamespacedName{
-		Namespace: storeNamespace,
-		Name:      binding.Store.Name,
-	}
-	store := &atomixv1beta1.Store{}
-	if err := r.client.Get(ctx, storeNamespacedName, store); err != nil {
+func (r *ProxyReconciler) reconcileBinding(ctx context.Context, endpoint *proxyEndpoint, proxy *atomixv1beta1.Proxy, binding atomixv1beta1.ProfileBinding) (bool, error) {
+	ctx, span := tracer.Start(ctx, "ProxyReconciler.reconcileBinding",
+		trace.WithAttributes(
+			attribute.String("proxy", getNamespacedName(proxy).String()),
+			attribute.String("binding", binding.Name),
+			attribute.String("store", getStoreLabel(getStoreID(proxy.Namespace, binding.Store)))))
+	defer span.End()
+	ok, err := r.updateBinding(ctx, endpoint, proxy, binding)
+	tracing.RecordError(span, err)
+	return ok, err
+}
+
+func (r *ProxyReconciler) updateBinding(ctx context.Context, endpoint *proxyEndpoint, proxy *atomixv1beta1.Proxy, binding atomixv1beta1.ProfileBinding) (bool, error) {
+	storeNamespacedName := getStoreID(proxy.Namespace, binding.Store)
+	store, storeSpec, err := getStore(ctx, r.client, binding.Store.Kind, storeNamespacedName)
+	if err != nil {
 		if !k8serrors.IsNotFound(err) {
 			log.Error(err)
 			return false, err
@@ -233,28 +558,10 @@ func (r *ProxyReconciler) reconcileBinding(ctx context.Context, pod *corev1.Pod,
 			if status.Name == binding.Name {
 				switch status.State {
 				case atomixv1beta1.BindingBound:
-					// Disconnect the binding in the pod
-					conn, err := connect(ctx, pod)
-					if err != nil {
-						log.Error(err)
-						return false, err
-					}
-
-					r.events.Eventf(pod, "Normal", "DisconnectStore", "Disconnecting store '%s'", storeNamespacedName)
-					client := proxyv1.NewProxyClient(conn)
-					request := &proxyv1.DisconnectRequest{
-						StoreID: proxyv1.StoreId{
-							Namespace: storeNamespacedName.Namespace,
-							Name:      storeNamespacedName.Name,
-						},
-					}
-					_, err = client.Disconnect(ctx, request)
-					if err != nil {
-						log.Error(err)
-						r.events.Eventf(pod, "Warning", "DisconnectStoreFailed", "Failed disconnecting from store '%s': %s", storeNamespacedName, err)
+					// Disconnect the binding in the proxy
+					if err := r.disconnect(ctx, endpoint, proxy, storeNamespacedName, atomixv1beta1.DriverReference{}); err != nil {
 						return false, err
 					}
-					r.events.Eventf(pod, "Normal", "DisconnectStoreSucceeded", "Successfully disconnected from store '%s'", storeNamespacedName)
 
 					// Update the binding status
 					status.State = atomixv1beta1.BindingUnbound
@@ -272,41 +579,68 @@ func (r *ProxyReconciler) reconcileBinding(ctx context.Context, pod *corev1.Pod,
 		return false, nil
 	}
 
+	// Verify the proxy's namespace is permitted to bind to the store
+	if ok, err := isStoreAccessGranted(ctx, r.client, proxy.Namespace, binding.Store.Kind, storeNamespacedName); err != nil {
+		log.Error(err)
+		return false, err
+	} else if !ok {
+		return r.rejectBinding(ctx, endpoint, proxy, binding, storeNamespacedName, storeSpec.Driver, atomixv1beta1.BindingDenied,
+			getStoreAccessDeniedMessage(proxy.Namespace, storeNamespacedName))
+	}
+
+	// Verify the store's driver is compatible with the proxy runtime
+	if message, err := r.checkDriverCompatibility(ctx, endpoint, proxy, storeSpec.Driver); err != nil {
+		log.Error(err)
+		return false, err
+	} else if message != "" {
+		return r.rejectBinding(ctx, endpoint, proxy, binding, storeNamespacedName, storeSpec.Driver, atomixv1beta1.BindingIncompatible, message)
+	}
+
 	for i, status := range proxy.Status.Bindings {
 		if status.Name == binding.Name {
 			switch status.State {
-			case atomixv1beta1.BindingUnbound:
-				// Connect the binding in the pod
-				conn, err := connect(ctx, pod)
-				if err != nil {
+			case atomixv1beta1.BindingUnbound, atomixv1beta1.BindingDenied, atomixv1beta1.BindingIncompatible:
+				// Stores already connected in a shared proxy by other pods on the node are not connected again
+				if shared, err := r.isStoreShared(ctx, endpoint, proxy, storeNamespacedName); err != nil {
 					log.Error(err)
 					return false, err
-				}
+				} else if !shared {
+					// Connect the binding in the proxy
+					conn, err := connect(ctx, endpoint)
+					if err != nil {
+						log.Error(err)
+						return false, err
+					}
+					defer conn.Close()
 
-				r.events.Eventf(pod, "Normal", "ConnectStore", "Connecting store '%s'", storeNamespacedName)
-				client := proxyv1.NewProxyClient(conn)
-				request := &proxyv1.ConnectRequest{
-					StoreID: proxyv1.StoreId{
-						Namespace: storeNamespacedName.Namespace,
-						Name:      storeNamespacedName.Name,
-					},
-					DriverID: proxyv1.DriverId{
-						Name:    store.Spec.Driver.Name,
-						Version: store.Spec.Driver.Version,
-					},
-					Config: store.Spec.Config.Raw,
-				}
-				_, err = client.Connect(ctx, request)
-				if err != nil {
-					log.Error(err)
-					r.events.Eventf(pod, "Warning", "ConnectStoreFailed", "Failed connecting to store '%s': %s", storeNamespacedName, err)
-					return false, err
+					r.events.Eventf(endpoint.object, "Normal", "ConnectStore", "Connecting store '%s'", storeNamespacedName)
+					client := proxyv1.NewProxyClient(conn)
+					request := &proxyv1.ConnectRequest{
+						StoreID: proxyv1.StoreId{
+							Namespace: storeNamespacedName.Namespace,
+							Name:      storeNamespacedName.Name,
+						},
+						DriverID: proxyv1.DriverId{
+							Name:    storeSpec.Driver.Name,
+							Version: storeSpec.Driver.Version,
+						},
+						Config: storeSpec.Config.Raw,
+					}
+					start := time.Now()
+					_, err = client.Connect(ctx, request)
+					observeProxyRequest(connectOperation, storeNamespacedName, storeSpec.Driver, start, err)
+					if err != nil {
+						log.Error(err)
+						r.events.Eventf(endpoint.object, "Warning", "ConnectStoreFailed", "Failed connecting to store '%s': %s", storeNamespacedName, err)
+						return false, err
+					}
+					r.events.Eventf(endpoint.object, "Normal", "ConnectStoreSucceeded", "Successfully connected to store '%s'", storeNamespacedName)
 				}
-				r.events.Eventf(pod, "Normal", "ConnectStoreSucceeded", "Successfully connected to store '%s'", storeNamespacedName)
 
 				// Update the binding status
 				status.State = atomixv1beta1.BindingBound
-				status.Version = store.ResourceVersion
+				status.Version = store.GetResourceVersion()
+				status.Message = ""
 				proxy.Status.Bindings[i] = status
 				if err := r.setStatus(ctx, proxy); err != nil {
 					log.Error(err)
@@ -314,33 +648,36 @@ func (r *ProxyReconciler) reconcileBinding(ctx context.Context, pod *corev1.Pod,
 				}
 				return true, nil
 			case atomixv1beta1.BindingBound:
-				if status.Version != store.ResourceVersion {
-					// Configure the binding in the pod
-					conn, err := connect(ctx, pod)
+				if status.Version != store.GetResourceVersion() {
+					// Configure the binding in the proxy
+					conn, err := connect(ctx, endpoint)
 					if err != nil {
 						log.Error(err)
 						return false, err
 					}
+					defer conn.Close()
 
-					r.events.Eventf(pod, "Normal", "ConfigureStore", "Configuring store '%s'", storeNamespacedName)
+					r.events.Eventf(endpoint.object, "Normal", "ConfigureStore", "Configuring store '%s'", storeNamespacedName)
 					client := proxyv1.NewProxyClient(conn)
 					request := &proxyv1.ConfigureRequest{
 						StoreID: proxyv1.StoreId{
 							Namespace: storeNamespacedName.Namespace,
 							Name:      storeNamespacedName.Name,
 						},
-						Config: store.Spec.Config.Raw,
+						Config: storeSpec.Config.Raw,
 					}
+					start := time.Now()
 					_, err = client.Configure(ctx, request)
+					observeProxyRequest(configureOperation, storeNamespacedName, storeSpec.Driver, start, err)
 					if err != nil {
-						r.events.Eventf(pod, "Warning", "ConfigureStoreFailed", "Failed reconfiguring store '%s': %s", storeNamespacedName, err)
+						r.events.Eventf(endpoint.object, "Warning", "ConfigureStoreFailed", "Failed reconfiguring store '%s': %s", storeNamespacedName, err)
 						log.Error(err)
 						return false, err
 					}
-					r.events.Eventf(pod, "Normal", "ConfigureStoreSucceeded", "Successfully configured store '%s'", storeNamespacedName)
+					r.events.Eventf(endpoint.object, "Normal", "ConfigureStoreSucceeded", "Successfully configured store '%s'", storeNamespacedName)
 
 					// Update the binding status
-					status.Version = store.ResourceVersion
+					status.Version = store.GetResourceVersion()
 					proxy.Status.Bindings[i] = status
 					if err := r.setStatus(ctx, proxy); err != nil {
 						log.Error(err)
@@ -365,16 +702,222 @@ func (r *ProxyReconciler) reconcileBinding(ctx context.Context, pod *corev1.Pod,
 	return true, nil
 }
 
-func connect(ctx context.Context, pod *corev1.Pod) (*grpc.ClientConn, error) {
-	target := fmt.Sprintf("%s:%d", pod.Status.PodIP, defaultProxyPort)
-	return grpc.DialContext(ctx, target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// checkDriverCompatibility checks whether the given driver is compatible with the proxy's runtime version.
+// If the driver is incompatible, a message describing the incompatibility is returned.
+func (r *ProxyReconciler) checkDriverCompatibility(ctx context.Context, endpoint *proxyEndpoint, proxy *atomixv1beta1.Proxy, ref atomixv1beta1.DriverReference) (string, error) {
+	driver, err := getDriver(ctx, r.client, ref.Name)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return "", err
+		}
+		log.Warnf("Cannot verify compatibility of driver '%s' with Proxy '%s': driver is not registered", ref.Name, getNamespacedName(proxy))
+		return "", nil
+	}
+
+	version, ok := getDriverVersion(driver, ref.Version)
+	if !ok {
+		return fmt.Sprintf("driver '%s' does not support version '%s'", ref.Name, ref.Version), nil
+	}
+
+	runtimeVersion := proxy.Status.RuntimeVersion
+	if runtimeVersion == "" {
+		log.Warnf("Cannot verify compatibility of driver '%s' with Proxy '%s': runtime version is unknown", ref.Name, getNamespacedName(proxy))
+		return "", nil
+	}
+
+	if !isRuntimeVersionCompatible(version, runtimeVersion) {
+		return fmt.Sprintf("driver '%s' version '%s' is not compatible with runtime version '%s'", ref.Name, ref.Version, runtimeVersion), nil
+	}
+	return "", nil
+}
+
+// rejectBinding sets the binding to the given rejected state, disconnecting the store if it's bound
+func (r *ProxyReconciler) rejectBinding(ctx context.Context, endpoint *proxyEndpoint, proxy *atomixv1beta1.Proxy, binding atomixv1beta1.ProfileBinding,
+	storeNamespacedName types.NamespacedName, driver atomixv1beta1.DriverReference, state atomixv1beta1.BindingState, message string) (bool, error) {
+	reason := fmt.Sprintf("BindStore%s", state)
+	for i, status := range proxy.Status.Bindings {
+		if status.Name == binding.Name {
+			if status.State == state && status.Message == message {
+				return false, nil
+			}
+
+			// Disconnect the binding in the proxy if it can no longer be bound
+			if status.State == atomixv1beta1.BindingBound {
+				if err := r.disconnect(ctx, endpoint, proxy, storeNamespacedName, driver); err != nil {
+					return false, err
+				}
+			}
+
+			r.events.Eventf(endpoint.object, "Warning", reason, "Cannot bind store '%s': %s", storeNamespacedName, message)
+
+			// Update the binding status
+			status.State = state
+			status.Version = ""
+			status.Message = message
+			proxy.Status.Bindings[i] = status
+			if err := r.setStatus(ctx, proxy); err != nil {
+				log.Error(err)
+				return false, err
+			}
+			return true, nil
+		}
+	}
+
+	r.events.Eventf(endpoint.object, "Warning", reason, "Cannot bind store '%s': %s", storeNamespacedName, message)
+	status := atomixv1beta1.BindingStatus{
+		Name:    binding.Name,
+		State:   state,
+		Message: message,
+	}
+	proxy.Status.Bindings = append(proxy.Status.Bindings, status)
+	if err := r.setStatus(ctx, proxy); err != nil {
+		log.Error(err)
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *ProxyReconciler) disconnect(ctx context.Context, endpoint *proxyEndpoint, proxy *atomixv1beta1.Proxy, storeNamespacedName types.NamespacedName, driver atomixv1beta1.DriverReference) error {
+	// Stores remain connected in a shared proxy while other pods on the node are bound to them
+	if shared, err := r.isStoreShared(ctx, endpoint, proxy, storeNamespacedName); err != nil {
+		log.Error(err)
+		return err
+	} else if shared {
+		log.Infof("Store '%s' is still bound by other pods on the node of Proxy '%s'", storeNamespacedName, getNamespacedName(proxy))
+		return nil
+	}
+
+	conn, err := connect(ctx, endpoint)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer conn.Close()
+
+	r.events.Eventf(endpoint.object, "Normal", "DisconnectStore", "Disconnecting store '%s'", storeNamespacedName)
+	client := proxyv1.NewProxyClient(conn)
+	request := &proxyv1.DisconnectRequest{
+		StoreID: proxyv1.StoreId{
+			Namespace: storeNamespacedName.Namespace,
+			Name:      storeNamespacedName.Name,
+		},
+	}
+	start := time.Now()
+	_, err = client.Disconnect(ctx, request)
+	observeProxyRequest(disconnectOperation, storeNamespacedName, driver, start, err)
+	if err != nil {
+		log.Error(err)
+		r.events.Eventf(endpoint.object, "Warning", "DisconnectStoreFailed", "Failed disconnecting from store '%s': %s", storeNamespacedName, err)
+		return err
+	}
+	r.events.Eventf(endpoint.object, "Normal", "DisconnectStoreSucceeded", "Successfully disconnected from store '%s'", storeNamespacedName)
+	return nil
+}
+
+// proxyEndpoint is the control endpoint of a proxy
+type proxyEndpoint struct {
+	// object is the object on which binding events are recorded
+	object      client.Object
+	address     string
+	credentials credentials.TransportCredentials
+	// shared indicates whether the proxy is shared by the pods on a node
+	shared bool
+	// nodeName is the name of the node on which a shared proxy is running
+	nodeName string
+	// dialer overrides the dialer used to connect to the proxy
+	dialer func(ctx context.Context, address string) (net.Conn, error)
+}
+
+// getPodEndpoint returns the control endpoint of the proxy injected into the given pod
+func getPodEndpoint(pod *corev1.Pod) *proxyEndpoint {
+	return &proxyEndpoint{
+		object:      pod,
+		address:     fmt.Sprintf("%s:%d", pod.Status.PodIP, defaultProxyPort),
+		credentials: insecure.NewCredentials(),
+	}
+}
+
+// getExternalEndpoint returns the control endpoint of a proxy running outside the cluster
+func (r *ProxyReconciler) getExternalEndpoint(ctx context.Context, proxy *atomixv1beta1.Proxy) (*proxyEndpoint, error) {
+	creds, err := r.getTransportCredentials(ctx, proxy.Namespace, proxy.Endpoint.TLS)
+	if err != nil {
+		return nil, err
+	}
+	return &proxyEndpoint{
+		object:      proxy,
+		address:     proxy.Endpoint.Address,
+		credentials: creds,
+	}, nil
+}
+
+// getTransportCredentials returns the credentials for connections to an external proxy with the given TLS configuration
+// Secrets are read from the API server rather than the cache to avoid caching all Secrets in the cluster.
+func (r *ProxyReconciler) getTransportCredentials(ctx context.Context, namespace string, config *atomixv1beta1.ProxyTLSConfig) (credentials.TransportCredentials, error) {
+	if config == nil {
+		return insecure.NewCredentials(), nil
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         config.ServerName,
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}
+	if config.SecretName != "" {
+		secret := &corev1.Secret{}
+		secretName := types.NamespacedName{
+			Namespace: namespace,
+			Name:      config.SecretName,
+		}
+		if err := r.reader.Get(ctx, secretName, secret); err != nil {
+			return nil, err
+		}
+
+		certPEM, keyPEM := secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]
+		if len(certPEM) > 0 && len(keyPEM) > 0 {
+			cert, err := tls.X509KeyPair(certPEM, keyPEM)
+			if err != nil {
+				return nil, err
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+		if caPEM := secret.Data[proxyTLSCAKey]; len(caPEM) > 0 {
+			certPool := x509.NewCertPool()
+			if !certPool.AppendCertsFromPEM(caPEM) {
+				return nil, fmt.Errorf("Secret '%s' contains no valid CA certificates", secretName)
+			}
+			tlsConfig.RootCAs = certPool
+		}
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// setTransport configures the endpoint to connect to the given proxy pod over the transport configured for the namespace
+func (r *ProxyReconciler) setTransport(endpoint *proxyEndpoint, namespace string, pod *corev1.Pod) {
+	if r.controllerConfig.Get().Proxy.GetTransport(namespace) == controllerconfig.PortForwardProxyTransport {
+		podNamespace, podName := pod.Namespace, pod.Name
+		endpoint.dialer = func(ctx context.Context, _ string) (net.Conn, error) {
+			return r.portForward.DialPod(ctx, podNamespace, podName, defaultProxyPort)
+		}
+	}
+}
+
+func connect(ctx context.Context, endpoint *proxyEndpoint) (*grpc.ClientConn, error) {
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(endpoint.credentials),
+		grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(otelgrpc.StreamClientInterceptor()),
+	}
+	if endpoint.dialer != nil {
+		opts = append(opts, grpc.WithContextDialer(endpoint.dialer))
+	}
+	return grpc.DialContext(ctx, endpoint.address, opts...)
 }
 
 // ProxyInjector is a mutating webhook that injects the proxy container into pods
 type ProxyInjector struct {
-	client  client.Client
-	scheme  *runtime.Scheme
-	decoder *admission.Decoder
+	client           client.Client
+	scheme           *runtime.Scheme
+	controllerConfig *controllerconfig.Watcher
+	decoder          *admission.Decoder
 }
 
 // InjectDecoder :
@@ -385,6 +928,12 @@ func (i *ProxyInjector) InjectDecoder(decoder *admission.Decoder) error {
 
 // Handle :
 func (i *ProxyInjector) Handle(ctx context.Context, request admission.Request) admission.Response {
+	response := i.inject(ctx, request)
+	injectionsTotal.WithLabelValues(getInjectionOutcome(response)).Inc()
+	return response
+}
+
+func (i *ProxyInjector) inject(ctx context.Context, request admission.Request) admission.Response {
 	log.Infof("Received admission request for Pod '%s'", request.UID)
 
 	// Decode the pod
@@ -413,19 +962,93 @@ func (i *ProxyInjector) Handle(ctx context.Context, request admission.Request) a
 		return admission.Allowed(fmt.Sprintf("'%s' annotation is '%s'", proxyInjectStatusAnnotation, injectedRuntime))
 	}
 
-	profileName, ok := pod.Annotations[proxyProfileAnnotation]
+	profile, ok := getPodProfile(pod)
 	if !ok {
 		log.Warnf("No profile specified for Pod '%s'", request.UID)
 		return admission.Denied(fmt.Sprintf("'%s' annotation not found", proxyProfileAnnotation))
 	}
+	if profile.Kind != profileKind && profile.Kind != clusterProfileKind {
+		log.Warnf("Invalid profile kind specified for Pod '%s'", request.UID)
+		return admission.Denied(fmt.Sprintf("'%s' annotation must be one of '%s' or '%s'", proxyProfileKindAnnotation, profileKind, clusterProfileKind))
+	}
+
+	proxyConfig := i.controllerConfig.Get().Proxy
+	if proxyConfig.Mode == controllerconfig.NodeProxyMode {
+		// Point the pod's containers to the proxy shared by the pods on the node rather than injecting a sidecar
+		injectNodeProxyEnv(pod)
+		pod.Annotations[proxyModeAnnotation] = string(controllerconfig.NodeProxyMode)
+	} else if err := i.injectSidecar(ctx, request, pod, profile, proxyConfig); err != nil {
+		log.Errorf("Runtime injection failed for Pod '%s'", request.UID, err)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	pod.Annotations[proxyInjectStatusAnnotation] = injectedStatus
+	if pod.Labels == nil {
+		pod.Labels = make(map[string]string)
+	}
+	pod.Labels[proxyInjectedLabel] = "true"
+	if proxyConfig.Mode != controllerconfig.NodeProxyMode {
+		// Label sidecar pods with their profile to select them in the profile's NetworkPolicy
+		pod.Labels[proxyProfileLabel] = getProfileLabelValue(profile.Name)
+		pod.Labels[proxyProfileKindLabel] = profile.Kind
+	}
+	if proxyConfig.RuntimeVersion != "" {
+		pod.Annotations[proxyRuntimeVersionAnnotation] = proxyConfig.RuntimeVersion
+	}
+
+	// Marshal the pod and return a patch response
+	marshaledPod, err := json.Marshal(pod)
+	if err != nil {
+		log.Errorf("Runtime injection failed for Pod '%s'", request.UID, err)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(request.Object.Raw, marshaledPod)
+}
 
-	pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{
+// injectSidecar injects the proxy container and the driver plugins required by the pod's profile into the pod
+func (i *ProxyInjector) injectSidecar(ctx context.Context, request admission.Request, pod *corev1.Pod, profile atomixv1beta1.ProfileReference, proxyConfig controllerconfig.ProxyConfig) error {
+	// Determine the driver plugins required by the pod's profile
+	var plugins []driverPlugin
+	if owner, spec, err := getProfile(ctx, i.client, request.Namespace, profile); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+		log.Warnf("Profile '%s' not found for Pod '%s'; skipping driver plugin injection", profile.Name, request.UID)
+	} else {
+		plugins, err = getDriverPlugins(ctx, i.client, request.Namespace, spec)
+		if err != nil {
+			return err
+		}
+
+		// Create the proxy configuration before the pod mounts it, refusing to mount a ConfigMap the profile doesn't own
+		if request.DryRun == nil || !*request.DryRun {
+			if err := reconcileProfileConfigMap(ctx, i.client, i.scheme, owner, request.Namespace, profile, *spec); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Add init containers to install the driver plugins into the shared plugins volume
+	for _, plugin := range plugins {
+		pod.Spec.InitContainers = append(pod.Spec.InitContainers, newDriverPluginContainer(plugin))
+	}
+
+	pod.Spec.Containers = append(pod.Spec.Containers, newProxyContainer(proxyConfig))
+	pod.Spec.Volumes = append(pod.Spec.Volumes, newProxyVolumes(profile)...)
+	return nil
+}
+
+// newProxyContainer returns the proxy container for pods bound to a profile
+func newProxyContainer(proxyConfig controllerconfig.ProxyConfig) corev1.Container {
+	return corev1.Container{
 		Name:            proxyContainerName,
-		Image:           getProxyImage(),
-		ImagePullPolicy: corev1.PullIfNotPresent,
+		Image:           proxyConfig.Image,
+		ImagePullPolicy: proxyConfig.ImagePullPolicy,
 		Args: []string{
 			"--config",
 			fmt.Sprintf("/etc/atomix/%s", configFile),
+			"--plugins",
+			pluginsPath,
 		},
 		Env: []corev1.EnvVar{
 			{
@@ -464,11 +1087,11 @@ func (i *ProxyInjector) Handle(ctx context.Context, request admission.Request) a
 		Ports: []corev1.ContainerPort{
 			{
 				Name:          "runtime",
-				ContainerPort: 5678,
+				ContainerPort: defaultRuntimePort,
 			},
 			{
 				Name:          "control",
-				ContainerPort: 5679,
+				ContainerPort: defaultProxyPort,
 			},
 		},
 		VolumeMounts: []corev1.VolumeMount{
@@ -477,27 +1100,35 @@ func (i *ProxyInjector) Handle(ctx context.Context, request admission.Request) a
 				ReadOnly:  true,
 				MountPath: "/etc/atomix",
 			},
+			{
+				Name:      pluginsVolumeName,
+				ReadOnly:  true,
+				MountPath: pluginsPath,
+			},
 		},
-	})
-	pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
-		Name: "config",
-		VolumeSource: corev1.VolumeSource{
-			ConfigMap: &corev1.ConfigMapVolumeSource{
-				LocalObjectReference: corev1.LocalObjectReference{
-					Name: profileName,
+	}
+}
+
+// newProxyVolumes returns the volumes mounted by the proxy container for the given profile
+func newProxyVolumes(profile atomixv1beta1.ProfileReference) []corev1.Volume {
+	return []corev1.Volume{
+		{
+			Name: "config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: getProfileConfigMapName(profile),
+					},
 				},
 			},
 		},
-	})
-	pod.Annotations[proxyInjectStatusAnnotation] = injectedStatus
-
-	// Marshal the pod and return a patch response
-	marshaledPod, err := json.Marshal(pod)
-	if err != nil {
-		log.Errorf("Runtime injection failed for Pod '%s'", request.UID, err)
-		return admission.Errored(http.StatusInternalServerError, err)
+		{
+			Name: pluginsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
 	}
-	return admission.PatchResponseFromRaw(request.Object.Raw, marshaledPod)
 }
 
 var _ admission.Handler = &ProxyInjector{}
@@ -0,0 +1,403 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"context"
+	atomixv1beta1 "github.com/atomix/controller/pkg/apis/atomix/v1beta1"
+	controllerconfig "github.com/atomix/controller/pkg/controller/config"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const proxyPoolLabel = "proxy.atomix.io/pool"
+
+const proxyPoolProfileIndex = "spec.profile"
+
+func addProxyPoolController(mgr manager.Manager, controllerConfig *controllerconfig.Watcher) error {
+	controllerOptions := controllerConfig.Get().GetController("proxy-pool-controller")
+
+	// Create a new controller
+	c, err := controller.New("proxy-pool-controller", mgr, controller.Options{
+		Reconciler: &ProxyPoolReconciler{
+			client:           mgr.GetClient(),
+			scheme:           mgr.GetScheme(),
+			controllerConfig: controllerConfig,
+		},
+		MaxConcurrentReconciles: controllerOptions.MaxConcurrentReconciles,
+		RateLimiter:             newRateLimiter(controllerOptions.RateLimiter),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Index proxy pools by profile
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &atomixv1beta1.ProxyPool{}, proxyPoolProfileIndex, func(object client.Object) []string {
+		profile := object.(*atomixv1beta1.ProxyPool).Spec.Profile
+		if profile.Kind == "" {
+			profile.Kind = profileKind
+		}
+		return []string{getPodProfileIndexValue(profile.Kind, profile.Name)}
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ProxyPools
+	err = c.Watch(&source.Kind{Type: &atomixv1beta1.ProxyPool{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to Deployments
+	err = c.Watch(&source.Kind{Type: &appsv1.Deployment{}}, &handler.EnqueueRequestForOwner{
+		OwnerType:    &atomixv1beta1.ProxyPool{},
+		IsController: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to Services
+	err = c.Watch(&source.Kind{Type: &corev1.Service{}}, &handler.EnqueueRequestForOwner{
+		OwnerType:    &atomixv1beta1.ProxyPool{},
+		IsController: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to NetworkPolicies
+	err = c.Watch(&source.Kind{Type: &networkingv1.NetworkPolicy{}}, &handler.EnqueueRequestForOwner{
+		OwnerType:    &atomixv1beta1.ProxyPool{},
+		IsController: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Reconcile all ProxyPools when the configuration changes to enable or disable their NetworkPolicies
+	err = c.Watch(newConfigSource(controllerConfig, func(ctx context.Context) ([]reconcile.Request, error) {
+		proxyPoolList := &atomixv1beta1.ProxyPoolList{}
+		if err := mgr.GetClient().List(ctx, proxyPoolList); err != nil {
+			return nil, err
+		}
+		var requests []reconcile.Request
+		for _, proxyPool := range proxyPoolList.Items {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: getNamespacedName(&proxyPool),
+			})
+		}
+		return requests, nil
+	}), &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to Profiles, which determine the driver plugins installed in the pool's proxies
+	err = c.Watch(&source.Kind{Type: &atomixv1beta1.Profile{}}, handler.EnqueueRequestsFromMapFunc(func(object client.Object) []reconcile.Request {
+		return getProfileProxyPoolRequests(mgr.GetClient(), object.GetNamespace(), profileKind, object.GetName())
+	}))
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterProfiles
+	err = c.Watch(&source.Kind{Type: &atomixv1beta1.ClusterProfile{}}, handler.EnqueueRequestsFromMapFunc(func(object client.Object) []reconcile.Request {
+		return getProfileProxyPoolRequests(mgr.GetClient(), metav1.NamespaceAll, clusterProfileKind, object.GetName())
+	}))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// getProfileProxyPoolRequests returns requests for the ProxyPools referencing the given profile
+func getProfileProxyPoolRequests(c client.Client, namespace string, kind string, name string) []reconcile.Request {
+	proxyPoolList := &atomixv1beta1.ProxyPoolList{}
+	if err := c.List(context.Background(), proxyPoolList, client.InNamespace(namespace),
+		client.MatchingFields{proxyPoolProfileIndex: getPodProfileIndexValue(kind, name)}); err != nil {
+		log.Error(err)
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(proxyPoolList.Items))
+	for _, proxyPool := range proxyPoolList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: getNamespacedName(&proxyPool),
+		})
+	}
+	return requests
+}
+
+// ProxyPoolReconciler is a Reconciler for ProxyPools
+type ProxyPoolReconciler struct {
+	client           client.Client
+	scheme           *runtime.Scheme
+	controllerConfig *controllerconfig.Watcher
+}
+
+// Reconcile reconciles ProxyPool resources
+func (r *ProxyPoolReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log.Infof("Reconciling ProxyPool '%s'", request.NamespacedName)
+	proxyPool := &atomixv1beta1.ProxyPool{}
+	err := r.client.Get(ctx, request.NamespacedName, proxyPool)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		log.Error(err)
+		return reconcile.Result{}, err
+	}
+
+	if proxyPool.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	if ok, err := r.reconcileDeployment(ctx, proxyPool); err != nil {
+		log.Error(err)
+		return reconcile.Result{}, err
+	} else if ok {
+		return reconcile.Result{}, nil
+	}
+
+	if ok, err := r.reconcileService(ctx, proxyPool); err != nil {
+		log.Error(err)
+		return reconcile.Result{}, err
+	} else if ok {
+		return reconcile.Result{}, nil
+	}
+
+	networkPolicyConfig := r.controllerConfig.Get().Proxy.NetworkPolicy
+	if err := reconcileNetworkPolicy(ctx, r.client, r.scheme, proxyPool, newProxyPoolNetworkPolicy(proxyPool), networkPolicyConfig.Enabled); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err := r.reconcileStatus(ctx, proxyPool); err != nil {
+		log.Error(err)
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+func (r *ProxyPoolReconciler) reconcileDeployment(ctx context.Context, proxyPool *atomixv1beta1.ProxyPool) (bool, error) {
+	desired, err := r.newDeployment(ctx, proxyPool)
+	if err != nil {
+		return false, err
+	}
+
+	deployment := &appsv1.Deployment{}
+	if err := r.client.Get(ctx, getNamespacedName(proxyPool), deployment); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return false, err
+		}
+		if err := controllerutil.SetControllerReference(proxyPool, desired, r.scheme); err != nil {
+			return false, err
+		}
+		log.Infof("Creating Deployment '%s'", getNamespacedName(desired))
+		if err := r.client.Create(ctx, desired); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	if !metav1.IsControlledBy(deployment, proxyPool) {
+		log.Warnf("Deployment '%s' is not controlled by ProxyPool '%s'", getNamespacedName(deployment), getNamespacedName(proxyPool))
+		return false, nil
+	}
+
+	if *deployment.Spec.Replicas != *desired.Spec.Replicas ||
+		!equality.Semantic.DeepDerivative(desired.Spec.Template, deployment.Spec.Template) {
+		log.Infof("Updating Deployment '%s'", getNamespacedName(deployment))
+		deployment.Spec.Replicas = desired.Spec.Replicas
+		deployment.Spec.Template = desired.Spec.Template
+		if err := r.client.Update(ctx, deployment); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// newDeployment returns the Deployment running the pool's proxies.
+// The pods are created already injected, so the pod controller creates a Proxy for each replica to keep its
+// bindings up to date, and the readiness gate removes replicas from the Service until their bindings are bound.
+func (r *ProxyPoolReconciler) newDeployment(ctx context.Context, proxyPool *atomixv1beta1.ProxyPool) (*appsv1.Deployment, error) {
+	profile := proxyPool.Spec.Profile
+	if profile.Kind == "" {
+		profile.Kind = profileKind
+	}
+
+	// Determine the driver plugins required by the pool's profile
+	var plugins []driverPlugin
+	if spec, err := getProfileSpec(ctx, r.client, proxyPool.Namespace, profile); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return nil, err
+		}
+		log.Warnf("Profile '%s' not found for ProxyPool '%s'; skipping driver plugin installation", profile.Name, getNamespacedName(proxyPool))
+	} else {
+		plugins, err = getDriverPlugins(ctx, r.client, proxyPool.Namespace, spec)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var initContainers []corev1.Container
+	for _, plugin := range plugins {
+		initContainers = append(initContainers, newDriverPluginContainer(plugin))
+	}
+
+	replicas := int32(1)
+	if proxyPool.Spec.Replicas != nil {
+		replicas = *proxyPool.Spec.Replicas
+	}
+
+	proxyConfig := r.controllerConfig.Get().Proxy
+	annotations := map[string]string{
+		proxyInjectStatusAnnotation: injectedStatus,
+		proxyProfileAnnotation:      profile.Name,
+		proxyProfileKindAnnotation:  profile.Kind,
+	}
+	if proxyConfig.RuntimeVersion != "" {
+		annotations[proxyRuntimeVersionAnnotation] = proxyConfig.RuntimeVersion
+	}
+
+	podLabels := getProxyPoolLabels(proxyPool)
+	podLabels[proxyInjectedLabel] = "true"
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: proxyPool.Namespace,
+			Name:      proxyPool.Name,
+			Labels:    getProxyPoolLabels(proxyPool),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: getProxyPoolLabels(proxyPool),
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      podLabels,
+					Annotations: annotations,
+				},
+				Spec: corev1.PodSpec{
+					InitContainers: initContainers,
+					Containers: []corev1.Container{
+						newProxyContainer(proxyConfig),
+					},
+					Volumes: newProxyVolumes(profile),
+					ReadinessGates: []corev1.PodReadinessGate{
+						{
+							ConditionType: atomixReadyCondition,
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func (r *ProxyPoolReconciler) reconcileService(ctx context.Context, proxyPool *atomixv1beta1.ProxyPool) (bool, error) {
+	desired := newProxyPoolService(proxyPool)
+	service := &corev1.Service{}
+	if err := r.client.Get(ctx, getNamespacedName(proxyPool), service); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return false, err
+		}
+		if err := controllerutil.SetControllerReference(proxyPool, desired, r.scheme); err != nil {
+			return false, err
+		}
+		log.Infof("Creating Service '%s'", getNamespacedName(desired))
+		if err := r.client.Create(ctx, desired); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	if !metav1.IsControlledBy(service, proxyPool) {
+		log.Warnf("Service '%s' is not controlled by ProxyPool '%s'", getNamespacedName(service), getNamespacedName(proxyPool))
+		return false, nil
+	}
+
+	if !equality.Semantic.DeepEqual(desired.Spec.Selector, service.Spec.Selector) ||
+		!equality.Semantic.DeepDerivative(desired.Spec.Ports, service.Spec.Ports) {
+		log.Infof("Updating Service '%s'", getNamespacedName(service))
+		service.Spec.Selector = desired.Spec.Selector
+		service.Spec.Ports = desired.Spec.Ports
+		if err := r.client.Update(ctx, service); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// newProxyPoolService returns the Service through which clients connect to the pool's proxies
+func newProxyPoolService(proxyPool *atomixv1beta1.ProxyPool) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: proxyPool.Namespace,
+			Name:      proxyPool.Name,
+			Labels:    getProxyPoolLabels(proxyPool),
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: getProxyPoolLabels(proxyPool),
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "runtime",
+					Port:       defaultRuntimePort,
+					TargetPort: intstr.FromString("runtime"),
+				},
+			},
+		},
+	}
+}
+
+func (r *ProxyPoolReconciler) reconcileStatus(ctx context.Context, proxyPool *atomixv1beta1.ProxyPool) error {
+	deployment := &appsv1.Deployment{}
+	if err := r.client.Get(ctx, getNamespacedName(proxyPool), deployment); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	status := atomixv1beta1.ProxyPoolStatus{
+		Replicas:      deployment.Status.Replicas,
+		ReadyReplicas: deployment.Status.ReadyReplicas,
+		Selector:      labels.SelectorFromSet(getProxyPoolLabels(proxyPool)).String(),
+		Service:       proxyPool.Name,
+	}
+	if proxyPool.Status == status {
+		return nil
+	}
+	proxyPool.Status = status
+	return r.client.Status().Update(ctx, proxyPool)
+}
+
+// getProxyPoolLabels returns the labels identifying the pods of the given pool
+// Pool names may be longer than label values, so long names are truncated and suffixed with a hash.
+func getProxyPoolLabels(proxyPool *atomixv1beta1.ProxyPool) map[string]string {
+	return map[string]string{
+		proxyPoolLabel: truncateName(proxyPool.Name, validation.LabelValueMaxLength),
+	}
+}
+
+var _ reconcile.Reconciler = &ProxyPoolReconciler{}
@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	atomixv1beta1 "github.com/atomix/controller/pkg/apis/atomix/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/json"
+	"net/http"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	storeKind        = "Store"
+	clusterStoreKind = "ClusterStore"
+)
+
+const storeDefaultPath = "/mutate-store"
+
+func addStoreWebhook(mgr manager.Manager) error {
+	mgr.GetWebhookServer().Register(storeDefaultPath, &webhook.Admission{
+		Handler: &StoreDefaulter{
+			client: mgr.GetClient(),
+			scheme: mgr.GetScheme(),
+		},
+	})
+	return nil
+}
+
+// StoreDefaulter is a mutating webhook that applies the defaults of a Store's Driver to its configuration
+// and validates the configuration against the Driver's schema
+type StoreDefaulter struct {
+	client  client.Client
+	scheme  *runtime.Scheme
+	decoder *admission.Decoder
+}
+
+// InjectDecoder :
+func (d *StoreDefaulter) InjectDecoder(decoder *admission.Decoder) error {
+	d.decoder = decoder
+	return nil
+}
+
+// Handle :
+func (d *StoreDefaulter) Handle(ctx context.Context, request admission.Request) admission.Response {
+	log.Infof("Received admission request for %s '%s'", request.Kind.Kind, request.UID)
+
+	// Decode the store
+	var object client.Object
+	var spec *atomixv1beta1.StoreSpec
+	switch request.Kind.Kind {
+	case storeKind:
+		store := &atomixv1beta1.Store{}
+		object, spec = store, &store.Spec
+	case clusterStoreKind:
+		clusterStore := &atomixv1beta1.ClusterStore{}
+		object, spec = clusterStore, &clusterStore.Spec
+	default:
+		return admission.Allowed(fmt.Sprintf("unknown kind '%s'", request.Kind.Kind))
+	}
+	if err := d.decoder.Decode(request, object); err != nil {
+		log.Errorf("Could not decode %s '%s'", request.Kind.Kind, request.UID, err)
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	driver, err := getDriver(ctx, d.client, spec.Driver.Name)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			// Drivers may be registered after the stores using them, so warn rather than deny
+			log.Warnf("Skipping validation for %s '%s': driver '%s' is not registered", request.Kind.Kind, request.UID, spec.Driver.Name)
+			return admission.Allowed("").WithWarnings(fmt.Sprintf("driver '%s' is not registered: the store's configuration was neither defaulted nor validated", spec.Driver.Name))
+		}
+		log.Errorf("Validation failed for %s '%s'", request.Kind.Kind, request.UID, err)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	version, ok := getDriverVersion(driver, spec.Driver.Version)
+	if !ok {
+		log.Warnf("Validation failed for %s '%s': driver '%s' does not support version '%s'", request.Kind.Kind, request.UID, spec.Driver.Name, spec.Driver.Version)
+		return admission.Denied(fmt.Sprintf("driver '%s' does not support version '%s'", spec.Driver.Name, spec.Driver.Version))
+	}
+
+	config, err := getDriverConfig(version, spec.Config.Raw)
+	if err != nil {
+		log.Warnf("Validation failed for %s '%s'", request.Kind.Kind, request.UID, err)
+		return admission.Denied(err.Error())
+	}
+	spec.Config.Raw = config
+
+	// Marshal the store and return a patch response
+	marshaledStore, err := json.Marshal(object)
+	if err != nil {
+		log.Errorf("Validation failed for %s '%s'", request.Kind.Kind, request.UID, err)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(request.Object.Raw, marshaledStore)
+}
+
+var _ admission.Handler = &StoreDefaulter{}
+
+// getStoreID returns the identifier of the store referenced from the given namespace.
+// ClusterStores are identified by name only.
+func getStoreID(namespace string, ref corev1.ObjectReference) types.NamespacedName {
+	if ref.Kind == clusterStoreKind {
+		return types.NamespacedName{
+			Name: ref.Name,
+		}
+	}
+	storeNamespace := ref.Namespace
+	if storeNamespace == "" {
+		storeNamespace = namespace
+	}
+	return types.NamespacedName{
+		Namespace: storeNamespace,
+		Name:      ref.Name,
+	}
+}
+
+// getStore gets the Store or ClusterStore with the given identifier
+func getStore(ctx context.Context, c client.Client, kind string, storeID types.NamespacedName) (client.Object, *atomixv1beta1.StoreSpec, error) {
+	switch kind {
+	case "", storeKind:
+		store := &atomixv1beta1.Store{}
+		if err := c.Get(ctx, storeID, store); err != nil {
+			return nil, nil, err
+		}
+		return store, &store.Spec, nil
+	case clusterStoreKind:
+		clusterStore := &atomixv1beta1.ClusterStore{}
+		if err := c.Get(ctx, storeID, clusterStore); err != nil {
+			return nil, nil, err
+		}
+		return clusterStore, &clusterStore.Spec, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown store kind '%s'", kind)
+	}
+}
+
+// isStoreAccessGranted returns whether the given namespace is permitted to bind to the given store.
+// Stores may always be bound from their own namespace, and ClusterStores may be bound from any namespace.
+// Stores in other namespaces may only be bound if a StoreGrant in the store's namespace permits it.
+func isStoreAccessGranted(ctx context.Context, c client.Client, namespace string, kind string, storeID types.NamespacedName) (bool, error) {
+	if kind == clusterStoreKind || storeID.Namespace == namespace {
+		return true, nil
+	}
+
+	grantList := &atomixv1beta1.StoreGrantList{}
+	if err := c.List(ctx, grantList, &client.ListOptions{Namespace: storeID.Namespace}); err != nil {
+		return false, err
+	}
+
+	for _, grant := range grantList.Items {
+		if isStoreGranted(grant.Spec, storeID.Name) && isNamespaceGranted(grant.Spec, namespace) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func isStoreGranted(spec atomixv1beta1.StoreGrantSpec, name string) bool {
+	if len(spec.Stores) == 0 {
+		return true
+	}
+	for _, store := range spec.Stores {
+		if store.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isNamespaceGranted(spec atomixv1beta1.StoreGrantSpec, namespace string) bool {
+	for _, grantedNamespace := range spec.Namespaces {
+		if grantedNamespace == "*" || grantedNamespace == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+func getStoreAccessDeniedMessage(namespace string, storeID types.NamespacedName) string {
+	return fmt.Sprintf("no StoreGrant in namespace '%s' permits namespace '%s' to bind to store '%s'", storeID.Namespace, namespace, storeID.Name)
+}
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"context"
+	atomixv1beta1 "github.com/atomix/controller/pkg/apis/atomix/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func TestIsStoreGranted(t *testing.T) {
+	tests := []struct {
+		name   string
+		stores []string
+		store  string
+		want   bool
+	}{
+		{name: "all stores", store: "foo", want: true},
+		{name: "listed store", stores: []string{"foo", "bar"}, store: "bar", want: true},
+		{name: "unlisted store", stores: []string{"foo", "bar"}, store: "baz", want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			spec := atomixv1beta1.StoreGrantSpec{}
+			for _, store := range test.stores {
+				spec.Stores = append(spec.Stores, corev1.LocalObjectReference{Name: store})
+			}
+			if got := isStoreGranted(spec, test.store); got != test.want {
+				t.Errorf("isStoreGranted() = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestIsNamespaceGranted(t *testing.T) {
+	tests := []struct {
+		name       string
+		namespaces []string
+		namespace  string
+		want       bool
+	}{
+		{name: "no namespaces", namespace: "foo", want: false},
+		{name: "listed namespace", namespaces: []string{"foo", "bar"}, namespace: "bar", want: true},
+		{name: "unlisted namespace", namespaces: []string{"foo", "bar"}, namespace: "baz", want: false},
+		{name: "wildcard", namespaces: []string{"*"}, namespace: "baz", want: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			spec := atomixv1beta1.StoreGrantSpec{Namespaces: test.namespaces}
+			if got := isNamespaceGranted(spec, test.namespace); got != test.want {
+				t.Errorf("isNamespaceGranted() = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestIsStoreAccessGranted(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := atomixv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	grant := &atomixv1beta1.StoreGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "stores",
+			Name:      "grant",
+		},
+		Spec: atomixv1beta1.StoreGrantSpec{
+			Namespaces: []string{"granted"},
+			Stores:     []corev1.LocalObjectReference{{Name: "shared"}},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(grant).Build()
+
+	tests := []struct {
+		name      string
+		namespace string
+		kind      string
+		store     types.NamespacedName
+		want      bool
+	}{
+		{name: "same namespace", namespace: "stores", kind: storeKind, store: types.NamespacedName{Namespace: "stores", Name: "private"}, want: true},
+		{name: "cluster store", namespace: "denied", kind: clusterStoreKind, store: types.NamespacedName{Name: "cluster"}, want: true},
+		{name: "granted", namespace: "granted", kind: storeKind, store: types.NamespacedName{Namespace: "stores", Name: "shared"}, want: true},
+		{name: "store not granted", namespace: "granted", kind: storeKind, store: types.NamespacedName{Namespace: "stores", Name: "private"}, want: false},
+		{name: "namespace not granted", namespace: "denied", kind: storeKind, store: types.NamespacedName{Namespace: "stores", Name: "shared"}, want: false},
+		{name: "no grants", namespace: "granted", kind: storeKind, store: types.NamespacedName{Namespace: "other", Name: "shared"}, want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := isStoreAccessGranted(context.TODO(), c, test.namespace, test.kind, test.store)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("isStoreAccessGranted() = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
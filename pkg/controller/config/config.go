@@ -0,0 +1,272 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	"os"
+	"time"
+)
+
+const (
+	// DefaultPath is the path from which the controller configuration is loaded
+	DefaultPath = "/etc/atomix/config/controller.yaml"
+)
+
+const (
+	proxyImageEnv     = "PROXY_IMAGE"
+	runtimeVersionEnv = "RUNTIME_VERSION"
+)
+
+const (
+	defaultWebhookPort             = 443
+	defaultCertDir                 = "/tmp/k8s-webhook-server/serving-certs"
+	defaultMetricsBindAddress      = ":8080"
+	defaultHealthProbeBindAddress  = ":8081"
+	defaultMaxConcurrentReconciles = 1
+	defaultBaseDelay               = 10 * time.Millisecond
+	defaultMaxDelay                = 5 * time.Second
+	defaultProxyImage              = "atomix/proxy:latest"
+	defaultLoggingFile             = "/etc/atomix/config/logging.yaml"
+)
+
+// ProxyMode is the mode in which proxies are deployed
+type ProxyMode string
+
+const (
+	// SidecarProxyMode injects a proxy container into each pod
+	SidecarProxyMode ProxyMode = "sidecar"
+	// NodeProxyMode shares a proxy deployed by a DaemonSet between the pods on each node
+	NodeProxyMode ProxyMode = "node"
+)
+
+// Config is the controller configuration
+type Config struct {
+	// Watch is the configuration of the namespaces watched by the controller
+	Watch WatchConfig `yaml:"watch"`
+	// Webhook is the webhook server configuration
+	Webhook WebhookConfig `yaml:"webhook"`
+	// Metrics is the metrics endpoint configuration
+	Metrics MetricsConfig `yaml:"metrics"`
+	// Health is the health probe endpoint configuration
+	Health HealthConfig `yaml:"health"`
+	// Controllers is the default configuration for all controllers
+	Controllers ControllerConfig `yaml:"controllers"`
+	// Controller overrides the default controller configuration for the named controllers
+	Controller map[string]ControllerConfig `yaml:"controller"`
+	// Proxy is the configuration for injected proxies
+	Proxy ProxyConfig `yaml:"proxy"`
+	// Logging is the controller logging configuration
+	Logging LoggingConfig `yaml:"logging"`
+}
+
+// WatchConfig is the configuration of the namespaces watched by the controller
+// If neither namespaces nor a namespace selector are configured, all namespaces are watched.
+type WatchConfig struct {
+	// Namespaces is the set of namespaces to watch
+	Namespaces []string `yaml:"namespaces"`
+	// NamespaceSelector is a label selector for additional namespaces to watch
+	NamespaceSelector string `yaml:"namespaceSelector"`
+}
+
+// WebhookConfig is the webhook server configuration
+type WebhookConfig struct {
+	// Port is the port on which the webhook server listens
+	Port int `yaml:"port"`
+	// CertDir is the directory from which the webhook server loads its serving certificate
+	CertDir string `yaml:"certDir"`
+}
+
+// MetricsConfig is the metrics endpoint configuration
+type MetricsConfig struct {
+	// BindAddress is the address to which the Prometheus metrics endpoint binds
+	BindAddress string `yaml:"bindAddress"`
+}
+
+// HealthConfig is the health probe endpoint configuration
+type HealthConfig struct {
+	// BindAddress is the address to which the health and readiness probe endpoints bind
+	BindAddress string `yaml:"bindAddress"`
+}
+
+// ControllerConfig is the configuration for a controller
+type ControllerConfig struct {
+	// MaxConcurrentReconciles is the maximum number of concurrent reconciles
+	MaxConcurrentReconciles int `yaml:"maxConcurrentReconciles"`
+	// RateLimiter is the configuration of the controller's rate limiter
+	RateLimiter RateLimiterConfig `yaml:"rateLimiter"`
+}
+
+// RateLimiterConfig is the configuration of a controller's exponential failure rate limiter
+type RateLimiterConfig struct {
+	// BaseDelay is the delay before the first retry of a failed request
+	BaseDelay time.Duration `yaml:"baseDelay"`
+	// MaxDelay is the maximum delay between retries of a failed request
+	MaxDelay time.Duration `yaml:"maxDelay"`
+}
+
+// ProxyTransport is the transport over which the controller connects to proxies
+type ProxyTransport string
+
+const (
+	// DirectProxyTransport connects to the proxy's pod IP
+	DirectProxyTransport ProxyTransport = "direct"
+	// PortForwardProxyTransport tunnels connections through the API server's pod port-forward subresource
+	PortForwardProxyTransport ProxyTransport = "port-forward"
+)
+
+// ProxyConfig is the configuration for injected proxies
+type ProxyConfig struct {
+	// Mode is the mode in which proxies are deployed
+	Mode ProxyMode `yaml:"mode"`
+	// Transport is the transport over which the controller connects to proxies
+	Transport ProxyTransport `yaml:"transport"`
+	// NamespaceTransports overrides the transport for proxies in the named namespaces
+	NamespaceTransports map[string]ProxyTransport `yaml:"namespaceTransports"`
+	// Image is the proxy image
+	Image string `yaml:"image"`
+	// ImagePullPolicy is the proxy image pull policy
+	ImagePullPolicy corev1.PullPolicy `yaml:"imagePullPolicy"`
+	// RuntimeVersion is the runtime version of the proxy image
+	RuntimeVersion string `yaml:"runtimeVersion"`
+	// NetworkPolicy is the configuration of the NetworkPolicies generated for proxies
+	NetworkPolicy NetworkPolicyConfig `yaml:"networkPolicy"`
+}
+
+// NetworkPolicyConfig is the configuration of the NetworkPolicies generated for proxies
+// When enabled, a NetworkPolicy is maintained for each profile allowing access to the proxy control
+// port only from the controller's pods and to the runtime port only from within the pod. Each ProxyPool
+// gets a policy of its own which also allows access to the runtime port through the pool's Service.
+type NetworkPolicyConfig struct {
+	// Enabled indicates whether NetworkPolicies are generated for proxies
+	Enabled bool `yaml:"enabled"`
+	// IsolateApplicationPorts indicates whether the generated policies also deny ingress to the application's ports
+	// By default, ingress to all ports other than the proxy's ports is allowed.
+	IsolateApplicationPorts bool `yaml:"isolateApplicationPorts"`
+}
+
+// LoggingConfig is the controller logging configuration
+type LoggingConfig struct {
+	// File is the path to the logging configuration file
+	File string `yaml:"file"`
+	// Level is the root log level, overriding the level in the logging configuration file
+	Level string `yaml:"level"`
+}
+
+// GetController returns the configuration for the named controller
+func (c Config) GetController(name string) ControllerConfig {
+	config := c.Controllers
+	if override, ok := c.Controller[name]; ok {
+		if override.MaxConcurrentReconciles != 0 {
+			config.MaxConcurrentReconciles = override.MaxConcurrentReconciles
+		}
+		if override.RateLimiter.BaseDelay != 0 {
+			config.RateLimiter.BaseDelay = override.RateLimiter.BaseDelay
+		}
+		if override.RateLimiter.MaxDelay != 0 {
+			config.RateLimiter.MaxDelay = override.RateLimiter.MaxDelay
+		}
+	}
+	return config
+}
+
+// GetTransport returns the transport over which to connect to proxies in the given namespace
+func (c ProxyConfig) GetTransport(namespace string) ProxyTransport {
+	if transport, ok := c.NamespaceTransports[namespace]; ok && transport != "" {
+		return transport
+	}
+	if c.Transport == "" {
+		return DirectProxyTransport
+	}
+	return c.Transport
+}
+
+// Default returns the default controller configuration
+// Proxy defaults are read from the environment for compatibility with existing deployments.
+func Default() Config {
+	proxyImage := os.Getenv(proxyImageEnv)
+	if proxyImage == "" {
+		proxyImage = defaultProxyImage
+	}
+	return Config{
+		Webhook: WebhookConfig{
+			Port:    defaultWebhookPort,
+			CertDir: defaultCertDir,
+		},
+		Metrics: MetricsConfig{
+			BindAddress: defaultMetricsBindAddress,
+		},
+		Health: HealthConfig{
+			BindAddress: defaultHealthProbeBindAddress,
+		},
+		Controllers: ControllerConfig{
+			MaxConcurrentReconciles: defaultMaxConcurrentReconciles,
+			RateLimiter: RateLimiterConfig{
+				BaseDelay: defaultBaseDelay,
+				MaxDelay:  defaultMaxDelay,
+			},
+		},
+		Proxy: ProxyConfig{
+			Mode:            SidecarProxyMode,
+			Transport:       DirectProxyTransport,
+			Image:           proxyImage,
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			RuntimeVersion:  os.Getenv(runtimeVersionEnv),
+		},
+		Logging: LoggingConfig{
+			File: defaultLoggingFile,
+		},
+	}
+}
+
+// Load loads the configuration from the given path over the defaults
+// If the file does not exist, the default configuration is returned.
+func Load(path string) (Config, error) {
+	config := Default()
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return config, nil
+		}
+		return config, err
+	}
+	if err := yaml.Unmarshal(bytes, &config); err != nil {
+		return config, err
+	}
+	if err := config.Validate(); err != nil {
+		return config, err
+	}
+	return config, nil
+}
+
+// Validate returns an error if the configuration is invalid
+func (c Config) Validate() error {
+	switch c.Proxy.Mode {
+	case SidecarProxyMode, NodeProxyMode:
+	default:
+		return fmt.Errorf("invalid proxy mode '%s'", c.Proxy.Mode)
+	}
+	if err := c.Proxy.Transport.validate(); err != nil {
+		return err
+	}
+	for namespace, transport := range c.Proxy.NamespaceTransports {
+		if err := transport.validate(); err != nil {
+			return fmt.Errorf("namespace '%s': %w", namespace, err)
+		}
+	}
+	return nil
+}
+
+func (t ProxyTransport) validate() error {
+	switch t {
+	case "", DirectProxyTransport, PortForwardProxyTransport:
+		return nil
+	default:
+		return fmt.Errorf("invalid proxy transport '%s'", t)
+	}
+}
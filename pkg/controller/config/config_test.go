@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*Config)
+		wantErr bool
+	}{
+		{
+			name:   "default",
+			update: func(*Config) {},
+		},
+		{
+			name: "node mode",
+			update: func(config *Config) {
+				config.Proxy.Mode = NodeProxyMode
+			},
+		},
+		{
+			name: "invalid mode",
+			update: func(config *Config) {
+				config.Proxy.Mode = "daemon"
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty mode",
+			update: func(config *Config) {
+				config.Proxy.Mode = ""
+			},
+			wantErr: true,
+		},
+		{
+			name: "port-forward transport",
+			update: func(config *Config) {
+				config.Proxy.Transport = PortForwardProxyTransport
+			},
+		},
+		{
+			name: "invalid transport",
+			update: func(config *Config) {
+				config.Proxy.Transport = "tunnel"
+			},
+			wantErr: true,
+		},
+		{
+			name: "namespace transports",
+			update: func(config *Config) {
+				config.Proxy.NamespaceTransports = map[string]ProxyTransport{
+					"foo": PortForwardProxyTransport,
+					"bar": DirectProxyTransport,
+					"baz": "",
+				}
+			},
+		},
+		{
+			name: "invalid namespace transport",
+			update: func(config *Config) {
+				config.Proxy.NamespaceTransports = map[string]ProxyTransport{
+					"foo": "tunnel",
+				}
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := Default()
+			test.update(&config)
+			if err := config.Validate(); (err != nil) != test.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %t", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetTransport(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    ProxyConfig
+		namespace string
+		want      ProxyTransport
+	}{
+		{
+			name:      "default",
+			namespace: "foo",
+			want:      DirectProxyTransport,
+		},
+		{
+			name:      "configured",
+			config:    ProxyConfig{Transport: PortForwardProxyTransport},
+			namespace: "foo",
+			want:      PortForwardProxyTransport,
+		},
+		{
+			name: "namespace override",
+			config: ProxyConfig{
+				Transport:           DirectProxyTransport,
+				NamespaceTransports: map[string]ProxyTransport{"foo": PortForwardProxyTransport},
+			},
+			namespace: "foo",
+			want:      PortForwardProxyTransport,
+		},
+		{
+			name: "other namespace",
+			config: ProxyConfig{
+				Transport:           DirectProxyTransport,
+				NamespaceTransports: map[string]ProxyTransport{"foo": PortForwardProxyTransport},
+			},
+			namespace: "bar",
+			want:      DirectProxyTransport,
+		},
+		{
+			name: "empty namespace override",
+			config: ProxyConfig{
+				Transport:           PortForwardProxyTransport,
+				NamespaceTransports: map[string]ProxyTransport{"foo": ""},
+			},
+			namespace: "foo",
+			want:      PortForwardProxyTransport,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.config.GetTransport(test.namespace); got != test.want {
+				t.Errorf("GetTransport() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			config: "proxy:\n  mode: node\n  transport: port-forward\n",
+		},
+		{
+			name:    "invalid mode",
+			config:  "proxy:\n  mode: daemon\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid transport",
+			config:  "proxy:\n  transport: tunnel\n",
+			wantErr: true,
+		},
+		{
+			name:    "malformed",
+			config:  "proxy: [",
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "controller.yaml")
+			if err := os.WriteFile(path, []byte(test.config), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Load(path); (err != nil) != test.wantErr {
+				t.Errorf("Load() error = %v, wantErr %t", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	config, err := Load(filepath.Join(t.TempDir(), "controller.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Proxy.Mode != SidecarProxyMode {
+		t.Errorf("Proxy.Mode = %s, want %s", config.Proxy.Mode, SidecarProxyMode)
+	}
+}
@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"github.com/atomix/runtime/pkg/logging"
+	"gopkg.in/yaml.v3"
+	"os"
+	"strings"
+)
+
+const rootLoggerName = "root"
+
+// ConfigureLogging applies the log levels in the given logging configuration
+// Levels are read from the logging configuration file, if it exists, and the root level is
+// overridden by the configured level, if any. Loggers not otherwise configured log at info level.
+func ConfigureLogging(config LoggingConfig) error {
+	loggingConfig := logging.Config{}
+	if config.File != "" {
+		bytes, err := os.ReadFile(config.File)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		} else if err == nil {
+			if err := yaml.Unmarshal(bytes, &loggingConfig); err != nil {
+				return err
+			}
+		}
+	}
+
+	rootLevel := logging.InfoLevel
+	if root, ok := loggingConfig.Loggers[rootLoggerName]; ok && root.Level != nil {
+		level, err := parseLevel(*root.Level)
+		if err != nil {
+			return err
+		}
+		rootLevel = level
+	}
+	if config.Level != "" {
+		level, err := parseLevel(config.Level)
+		if err != nil {
+			return err
+		}
+		rootLevel = level
+	}
+	logging.SetLevel(rootLevel)
+
+	for name, loggerConfig := range loggingConfig.Loggers {
+		if name == rootLoggerName || loggerConfig.Level == nil {
+			continue
+		}
+		level, err := parseLevel(*loggerConfig.Level)
+		if err != nil {
+			return err
+		}
+		logging.GetLogger(name).SetLevel(level)
+	}
+	return nil
+}
+
+func parseLevel(level string) (logging.Level, error) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return logging.DebugLevel, nil
+	case "info":
+		return logging.InfoLevel, nil
+	case "warn":
+		return logging.WarnLevel, nil
+	case "error":
+		return logging.ErrorLevel, nil
+	case "fatal":
+		return logging.FatalLevel, nil
+	case "panic":
+		return logging.PanicLevel, nil
+	}
+	return logging.EmptyLevel, fmt.Errorf("unknown log level '%s'", level)
+}
@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"github.com/atomix/runtime/pkg/logging"
+	"github.com/fsnotify/fsnotify"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var log = logging.GetLogger()
+
+// NewWatcher loads the configuration from the given path and returns a Watcher for changes to it.
+// The given overrides are applied to the configuration each time it's loaded.
+func NewWatcher(path string, overrides ...func(*Config)) (*Watcher, error) {
+	w := &Watcher{
+		path:      path,
+		overrides: overrides,
+	}
+	config, err := w.load()
+	if err != nil {
+		return nil, err
+	}
+	w.config = config
+	return w, nil
+}
+
+// Watcher provides the current controller configuration, reloading it when the file changes.
+// Only changes to settings that can safely be applied at runtime take effect without a restart:
+// listeners are notified of changes, and controllers read the configuration when it's used.
+type Watcher struct {
+	path      string
+	overrides []func(*Config)
+	config    Config
+	pending   []string
+	listeners []func(Config)
+	mu        sync.RWMutex
+}
+
+// Get returns the current configuration
+func (w *Watcher) Get() Config {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.config
+}
+
+// Watch registers a listener to be called with the configuration each time it changes
+func (w *Watcher) Watch(listener func(Config)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.listeners = append(w.listeners, listener)
+}
+
+// Start watches the configuration file for changes until the context is cancelled
+func (w *Watcher) Start(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	// Watch the directory rather than the file, since ConfigMap volumes are updated by replacing a symlink
+	if err := watcher.Add(filepath.Dir(w.path)); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op&(fsnotify.Create|fsnotify.Write|fsnotify.Remove|fsnotify.Rename) != 0 {
+				w.reload()
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			log.Error(err)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// NeedLeaderElection indicates the configuration must be watched on all replicas
+func (w *Watcher) NeedLeaderElection() bool {
+	return false
+}
+
+func (w *Watcher) load() (Config, error) {
+	config, err := Load(w.path)
+	if err != nil {
+		return config, err
+	}
+	for _, override := range w.overrides {
+		override(&config)
+	}
+	return config, nil
+}
+
+func (w *Watcher) reload() {
+	loaded, err := w.load()
+	if err != nil {
+		log.Errorf("Failed to reload configuration from %s: %s", w.path, err)
+		return
+	}
+
+	w.mu.Lock()
+	previous := w.config
+	config, pending := withRestartOnlyFields(loaded, previous)
+	// Only warn when the set of pending changes changes, since every file event reloads the configuration
+	warn := len(pending) > 0 && !reflect.DeepEqual(pending, w.pending)
+	w.config = config
+	w.pending = pending
+	listeners := w.listeners
+	w.mu.Unlock()
+
+	if warn {
+		log.Warnf("Changes to %s in %s take effect on restart", strings.Join(pending, ", "), w.path)
+	}
+
+	// A single ConfigMap update produces several file events, most of which don't change the configuration
+	if reflect.DeepEqual(previous, config) {
+		return
+	}
+	log.Infof("Reloaded configuration from %s", w.path)
+	for _, listener := range listeners {
+		listener(config)
+	}
+}
+
+// getRestartOnlyFields returns pointers to the fields of the given configuration that only take effect on restart
+func getRestartOnlyFields(config *Config) map[string]interface{} {
+	return map[string]interface{}{
+		"watch":       &config.Watch,
+		"webhook":     &config.Webhook,
+		"metrics":     &config.Metrics,
+		"health":      &config.Health,
+		"controllers": &config.Controllers,
+		"controller":  &config.Controller,
+		// Pods injected in one proxy mode can't be served by proxies deployed in another
+		"proxy.mode": &config.Proxy.Mode,
+	}
+}
+
+// withRestartOnlyFields returns the given configuration with its restart-only fields replaced by those of the
+// current configuration, along with the names of the restart-only fields that differ between them
+func withRestartOnlyFields(config Config, current Config) (Config, []string) {
+	currentFields := getRestartOnlyFields(&current)
+	var changed []string
+	for name, field := range getRestartOnlyFields(&config) {
+		value := reflect.ValueOf(field).Elem()
+		currentValue := reflect.ValueOf(currentFields[name]).Elem()
+		changed = append(changed, getChangedFields(name, currentValue, value)...)
+		value.Set(currentValue)
+	}
+	sort.Strings(changed)
+	return config, changed
+}
+
+// getChangedFields returns the names of the fields that differ between the given values
+// Structs are compared field by field, so only the names of the changed leaf fields are returned.
+func getChangedFields(name string, previous, next reflect.Value) []string {
+	if previous.Kind() != reflect.Struct {
+		if reflect.DeepEqual(previous.Interface(), next.Interface()) {
+			return nil
+		}
+		return []string{name}
+	}
+	var changed []string
+	for i := 0; i < previous.NumField(); i++ {
+		field := previous.Type().Field(i)
+		fieldName := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if fieldName == "" {
+			fieldName = field.Name
+		}
+		changed = append(changed, getChangedFields(fmt.Sprintf("%s.%s", name, fieldName), previous.Field(i), next.Field(i))...)
+	}
+	return changed
+}
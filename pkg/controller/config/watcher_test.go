@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWithRestartOnlyFields(t *testing.T) {
+	tests := []struct {
+		name        string
+		update      func(*Config)
+		wantChanged []string
+	}{
+		{
+			name:   "no changes",
+			update: func(*Config) {},
+		},
+		{
+			name: "reloadable field",
+			update: func(config *Config) {
+				config.Proxy.Transport = PortForwardProxyTransport
+				config.Proxy.NetworkPolicy.Enabled = true
+			},
+		},
+		{
+			name: "proxy mode",
+			update: func(config *Config) {
+				config.Proxy.Mode = NodeProxyMode
+			},
+			wantChanged: []string{"proxy.mode"},
+		},
+		{
+			name: "nested fields",
+			update: func(config *Config) {
+				config.Webhook.Port = 8443
+				config.Controllers.RateLimiter.MaxDelay *= 2
+				config.Watch.Namespaces = []string{"foo"}
+			},
+			wantChanged: []string{"controllers.rateLimiter.maxDelay", "watch.namespaces", "webhook.port"},
+		},
+		{
+			name: "controller overrides",
+			update: func(config *Config) {
+				config.Controller = map[string]ControllerConfig{"proxy-controller": {MaxConcurrentReconciles: 2}}
+			},
+			wantChanged: []string{"controller"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			current := Default()
+			loaded := Default()
+			test.update(&loaded)
+
+			got, changed := withRestartOnlyFields(loaded, current)
+			if !reflect.DeepEqual(changed, test.wantChanged) {
+				t.Errorf("changed = %v, want %v", changed, test.wantChanged)
+			}
+
+			// Only the reloadable fields of the loaded configuration take effect
+			want := loaded
+			want.Watch = current.Watch
+			want.Webhook = current.Webhook
+			want.Metrics = current.Metrics
+			want.Health = current.Health
+			want.Controllers = current.Controllers
+			want.Controller = current.Controller
+			want.Proxy.Mode = current.Proxy.Mode
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("withRestartOnlyFields() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestWatcherReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "controller.yaml")
+	write := func(config string) {
+		if err := os.WriteFile(path, []byte(config), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("proxy:\n  mode: sidecar\n")
+	watcher, err := NewWatcher(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var notified []Config
+	watcher.Watch(func(config Config) {
+		notified = append(notified, config)
+	})
+
+	// Restart-only changes are neither applied nor notified
+	write("proxy:\n  mode: node\nwebhook:\n  port: 8443\n")
+	watcher.reload()
+	if config := watcher.Get(); config.Proxy.Mode != SidecarProxyMode || config.Webhook.Port != defaultWebhookPort {
+		t.Errorf("restart-only changes were applied: %+v", config)
+	}
+	if want := []string{"proxy.mode", "webhook.port"}; !reflect.DeepEqual(watcher.pending, want) {
+		t.Errorf("pending = %v, want %v", watcher.pending, want)
+	}
+	if len(notified) != 0 {
+		t.Errorf("listeners notified %d times, want 0", len(notified))
+	}
+
+	// Reloadable changes are applied while the restart-only changes remain pending
+	write("proxy:\n  mode: node\n  transport: port-forward\nwebhook:\n  port: 8443\n")
+	watcher.reload()
+	if config := watcher.Get(); config.Proxy.Transport != PortForwardProxyTransport || config.Proxy.Mode != SidecarProxyMode {
+		t.Errorf("unexpected configuration %+v", config)
+	}
+	if len(notified) != 1 {
+		t.Errorf("listeners notified %d times, want 1", len(notified))
+	}
+
+	// Reverting the restart-only changes clears them
+	write("proxy:\n  mode: sidecar\n  transport: port-forward\n")
+	watcher.reload()
+	if len(watcher.pending) != 0 {
+		t.Errorf("pending = %v, want none", watcher.pending)
+	}
+	if len(notified) != 1 {
+		t.Errorf("listeners notified %d times, want 1", len(notified))
+	}
+
+	// Invalid configurations are ignored
+	write("proxy:\n  mode: daemon\n")
+	watcher.reload()
+	if config := watcher.Get(); config.Proxy.Transport != PortForwardProxyTransport {
+		t.Errorf("invalid configuration was applied: %+v", config)
+	}
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certs
+
+import (
+	"time"
+)
+
+// caValidityFactor is the lifetime of a CA as a multiple of the lifetime of the certificates it issues
+const caValidityFactor = 2
+
+// Bundle is a CA and a serving certificate issued by it
+type Bundle struct {
+	CA      *KeyPair
+	Serving *KeyPair
+}
+
+// NewBundle generates a new CA and a serving certificate for the given service
+func NewBundle(service, namespace string, validity time.Duration) (*Bundle, error) {
+	return RenewBundle(nil, service, namespace, validity)
+}
+
+// RenewBundle issues a new serving certificate for the given service.
+// The CA in the current bundle is reused unless it would expire before the new certificate, in which
+// case a new CA is generated.
+func RenewBundle(current *Bundle, service, namespace string, validity time.Duration) (*Bundle, error) {
+	var ca *KeyPair
+	if current != nil && current.CA != nil && time.Now().Add(validity).Before(current.CA.Cert.NotAfter) {
+		ca = current.CA
+	} else {
+		log.Info("Generating webhook CA")
+		newCA, err := NewCA(validity * caValidityFactor)
+		if err != nil {
+			return nil, err
+		}
+		ca = newCA
+	}
+
+	log.Info("Generating webhook certificate")
+	serving, err := NewServingCert(ca, service, namespace, validity)
+	if err != nil {
+		return nil, err
+	}
+	return &Bundle{
+		CA:      ca,
+		Serving: serving,
+	}, nil
+}
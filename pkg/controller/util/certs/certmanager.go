@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// InjectCAFromAnnotation is an annotation on webhook configurations naming the '<namespace>/<name>'
+// of the certificates Secret from which the controller injects the CA bundle
+const InjectCAFromAnnotation = "atomix.io/inject-ca-from"
+
+const secretResyncPeriod = 10 * time.Minute
+
+// NewSecretWatcher creates a new SecretWatcher
+func NewSecretWatcher(client kubernetes.Interface, crdClient apiextensions.Interface, options Options) *SecretWatcher {
+	return &SecretWatcher{
+		client:    client,
+		crdClient: crdClient,
+		options:   options,
+	}
+}
+
+// SecretWatcher loads the webhook serving certificate from a Secret issued by an external
+// certificate manager like cert-manager.
+// The certificate is written to the webhook server's certificate directory whenever the Secret
+// is renewed, and the Secret's CA is injected into the CA bundle of the controller's webhook
+// configuration and conversion webhooks and of all webhook configurations annotated to inject
+// the CA from the Secret.
+type SecretWatcher struct {
+	client    kubernetes.Interface
+	crdClient apiextensions.Interface
+	options   Options
+}
+
+// Load waits for the certificates Secret to be issued and loads it
+func (w *SecretWatcher) Load(ctx context.Context, timeout time.Duration) error {
+	return wait.PollImmediate(time.Second, timeout, func() (bool, error) {
+		secret, err := w.client.CoreV1().Secrets(w.options.Namespace).Get(ctx, w.options.Secret, metav1.GetOptions{})
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				log.Infof("Waiting for certificates Secret '%s/%s'", w.options.Namespace, w.options.Secret)
+				return false, nil
+			}
+			return false, err
+		}
+		if err := w.load(ctx, secret); err != nil {
+			log.Warn(err)
+			return false, nil
+		}
+		return true, nil
+	})
+}
+
+// Start starts watching the certificates Secret for renewals
+func (w *SecretWatcher) Start(ctx context.Context) error {
+	factory := informers.NewSharedInformerFactoryWithOptions(w.client, secretResyncPeriod,
+		informers.WithNamespace(w.options.Namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", w.options.Secret).String()
+		}))
+	informer := factory.Core().V1().Secrets().Informer()
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if err := w.load(ctx, obj.(*corev1.Secret)); err != nil {
+				log.Error(err)
+			}
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			if err := w.load(ctx, obj.(*corev1.Secret)); err != nil {
+				log.Error(err)
+			}
+		},
+	})
+	factory.Start(ctx.Done())
+	<-ctx.Done()
+	return nil
+}
+
+// NeedLeaderElection indicates certificates must be loaded on all replicas, since the
+// webhook server runs on every replica regardless of leadership
+func (w *SecretWatcher) NeedLeaderElection() bool {
+	return false
+}
+
+// load writes the certificate in the given Secret to the certificate directory and injects its CA
+func (w *SecretWatcher) load(ctx context.Context, secret *corev1.Secret) error {
+	certPEM, keyPEM, caPEM := secret.Data[CertFile], secret.Data[KeyFile], secret.Data[CAFile]
+	if len(certPEM) == 0 || len(keyPEM) == 0 {
+		return fmt.Errorf("certificates Secret '%s/%s' has not been issued", secret.Namespace, secret.Name)
+	}
+	if len(caPEM) == 0 {
+		return fmt.Errorf("certificates Secret '%s/%s' has no '%s'", secret.Namespace, secret.Name, CAFile)
+	}
+
+	// Trust the CA before serving certificates issued by it
+	if err := PatchCABundle(ctx, w.client, w.options.WebhookConfiguration, caPEM); err != nil {
+		return err
+	}
+	if err := PatchInjectedCABundles(ctx, w.client, fmt.Sprintf("%s/%s", secret.Namespace, secret.Name), caPEM); err != nil {
+		return err
+	}
+	if err := PatchConversionWebhooks(ctx, w.crdClient, w.options.CustomResourceDefinitions,
+		w.options.Namespace, w.options.Service, w.options.ConversionWebhookPath, caPEM); err != nil {
+		return err
+	}
+
+	current, err := os.ReadFile(filepath.Join(w.options.CertDir, CertFile))
+	if err == nil && bytes.Equal(current, certPEM) {
+		return nil
+	} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	log.Infof("Loading webhook certificate from Secret '%s/%s'", secret.Namespace, secret.Name)
+	return writeKeyPairFiles(w.options.CertDir, certPEM, keyPEM)
+}
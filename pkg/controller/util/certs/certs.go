@@ -0,0 +1,217 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certs
+
+import (
+	"bytes"
+	cryptorand "crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// CertFile is the name of the serving certificate file
+	CertFile = "tls.crt"
+	// KeyFile is the name of the serving key file
+	KeyFile = "tls.key"
+)
+
+const (
+	organization = "Open Networking Foundation"
+	keySize      = 4096
+	serialBits   = 128
+)
+
+// KeyPair is a certificate and its private key
+type KeyPair struct {
+	Cert    *x509.Certificate
+	Key     *rsa.PrivateKey
+	CertPEM []byte
+	KeyPEM  []byte
+}
+
+// NewCA generates a new self-signed CA valid for the given duration
+func NewCA(validity time.Duration) (*KeyPair, error) {
+	serialNumber, err := newSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := rsa.GenerateKey(cryptorand.Reader, keySize)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			Organization: []string{organization},
+		},
+		NotBefore:             now.Add(-time.Minute),
+		NotAfter:              now.Add(validity),
+		IsCA:                  true,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	return newKeyPair(template, template, key, key)
+}
+
+// NewServingCert generates a new serving certificate for the given service signed by the given CA.
+// The certificate is valid for the given duration or until the CA expires, whichever is sooner.
+func NewServingCert(ca *KeyPair, service, namespace string, validity time.Duration) (*KeyPair, error) {
+	serialNumber, err := newSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := rsa.GenerateKey(cryptorand.Reader, keySize)
+	if err != nil {
+		return nil, err
+	}
+
+	subjectKeyID, err := getSubjectKeyID(key)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	notAfter := now.Add(validity)
+	if notAfter.After(ca.Cert.NotAfter) {
+		notAfter = ca.Cert.NotAfter
+	}
+
+	template := &x509.Certificate{
+		DNSNames: []string{
+			service,
+			fmt.Sprintf("%s.%s", service, namespace),
+			fmt.Sprintf("%s.%s.svc", service, namespace),
+		},
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			CommonName:   fmt.Sprintf("%s.%s.svc", service, namespace),
+			Organization: []string{organization},
+		},
+		NotBefore:    now.Add(-time.Minute),
+		NotAfter:     notAfter,
+		SubjectKeyId: subjectKeyID,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	return newKeyPair(template, ca.Cert, key, ca.Key)
+}
+
+// ParseKeyPair parses a PEM encoded certificate and private key
+func ParseKeyPair(certPEM, keyPEM []byte) (*KeyPair, error) {
+	certBlock, _ := pem.Decode(certPEM)
+	if certBlock == nil {
+		return nil, errors.New("failed to decode certificate PEM")
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil {
+		return nil, errors.New("failed to decode private key PEM")
+	}
+	key, err := x509.ParsePKCS1PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyPair{
+		Cert:    cert,
+		Key:     key,
+		CertPEM: certPEM,
+		KeyPEM:  keyPEM,
+	}, nil
+}
+
+// ReadKeyPair reads a serving certificate and key from the given directory
+func ReadKeyPair(dir string) (*KeyPair, error) {
+	certPEM, err := os.ReadFile(filepath.Join(dir, CertFile))
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := os.ReadFile(filepath.Join(dir, KeyFile))
+	if err != nil {
+		return nil, err
+	}
+	return ParseKeyPair(certPEM, keyPEM)
+}
+
+// WriteKeyPair writes a serving certificate and key to the given directory.
+// The key is written before the certificate so that watchers reloading the pair
+// on changes to either file observe a consistent pair once the certificate is written.
+func WriteKeyPair(dir string, keyPair *KeyPair) error {
+	return writeKeyPairFiles(dir, keyPair.CertPEM, keyPair.KeyPEM)
+}
+
+func writeKeyPairFiles(dir string, certPEM, keyPEM []byte) error {
+	if err := os.WriteFile(filepath.Join(dir, KeyFile), keyPEM, 0600); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, CertFile), certPEM, 0644)
+}
+
+func newKeyPair(template, parent *x509.Certificate, key *rsa.PrivateKey, parentKey *rsa.PrivateKey) (*KeyPair, error) {
+	certBytes, err := x509.CreateCertificate(cryptorand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	certPEM := new(bytes.Buffer)
+	if err := pem.Encode(certPEM, &pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: certBytes,
+	}); err != nil {
+		return nil, err
+	}
+
+	keyPEM := new(bytes.Buffer)
+	if err := pem.Encode(keyPEM, &pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	}); err != nil {
+		return nil, err
+	}
+	return &KeyPair{
+		Cert:    cert,
+		Key:     key,
+		CertPEM: certPEM.Bytes(),
+		KeyPEM:  keyPEM.Bytes(),
+	}, nil
+}
+
+// newSerialNumber generates a random certificate serial number
+func newSerialNumber() (*big.Int, error) {
+	return cryptorand.Int(cryptorand.Reader, new(big.Int).Lsh(big.NewInt(1), serialBits))
+}
+
+// getSubjectKeyID computes the subject key identifier for the given key as described in RFC 5280
+func getSubjectKeyID(key *rsa.PrivateKey) ([]byte, error) {
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha1.Sum(publicKeyBytes)
+	return hash[:], nil
+}
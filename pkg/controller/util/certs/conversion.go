@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certs
+
+import (
+	"bytes"
+	"context"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// PatchConversionWebhooks configures the named CustomResourceDefinitions to convert between versions using
+// the webhook served by the given service at the given path, adding the given CA to the webhook's CA bundle.
+// CustomResourceDefinitions that are not installed are ignored.
+func PatchConversionWebhooks(ctx context.Context, client apiextensions.Interface, names []string, namespace, service, path string, caPEM []byte) error {
+	for _, name := range names {
+		if err := patchConversionWebhook(ctx, client, name, namespace, service, path, caPEM); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func patchConversionWebhook(ctx context.Context, client apiextensions.Interface, name, namespace, service, path string, caPEM []byte) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		crd, err := client.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				log.Warnf("CustomResourceDefinition '%s' not found; skipping conversion webhook configuration", name)
+				return nil
+			}
+			return err
+		}
+
+		var caBundle []byte
+		if crd.Spec.Conversion != nil && crd.Spec.Conversion.Webhook != nil && crd.Spec.Conversion.Webhook.ClientConfig != nil {
+			caBundle = crd.Spec.Conversion.Webhook.ClientConfig.CABundle
+		}
+		conversion := &apiextensionsv1.CustomResourceConversion{
+			Strategy: apiextensionsv1.WebhookConverter,
+			Webhook: &apiextensionsv1.WebhookConversion{
+				ClientConfig: &apiextensionsv1.WebhookClientConfig{
+					Service: &apiextensionsv1.ServiceReference{
+						Namespace: namespace,
+						Name:      service,
+						Path:      &path,
+					},
+					CABundle: mergeCABundle(caBundle, caPEM),
+				},
+				ConversionReviewVersions: []string{"v1"},
+			},
+		}
+
+		if isConversionWebhook(crd.Spec.Conversion, conversion) {
+			return nil
+		}
+
+		crd.Spec.Conversion = conversion
+		_, err = client.ApiextensionsV1().CustomResourceDefinitions().Update(ctx, crd, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// isConversionWebhook returns whether the current conversion is the given webhook conversion
+func isConversionWebhook(current, conversion *apiextensionsv1.CustomResourceConversion) bool {
+	if current == nil || current.Strategy != conversion.Strategy || current.Webhook == nil || current.Webhook.ClientConfig == nil {
+		return false
+	}
+	currentConfig, config := current.Webhook.ClientConfig, conversion.Webhook.ClientConfig
+	if currentConfig.Service == nil || currentConfig.Service.Path == nil {
+		return false
+	}
+	return currentConfig.Service.Namespace == config.Service.Namespace &&
+		currentConfig.Service.Name == config.Service.Name &&
+		*currentConfig.Service.Path == *config.Service.Path &&
+		bytes.Equal(currentConfig.CABundle, config.CABundle)
+}
@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"time"
+)
+
+// NewChecker returns a readiness check that passes while a valid serving certificate is loaded from the given directory
+func NewChecker(dir string) healthz.Checker {
+	return func(_ *http.Request) error {
+		cert, err := tls.LoadX509KeyPair(filepath.Join(dir, CertFile), filepath.Join(dir, KeyFile))
+		if err != nil {
+			return err
+		}
+		if len(cert.Certificate) == 0 {
+			return fmt.Errorf("no certificate found in %s", dir)
+		}
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			return err
+		}
+		if time.Now().After(leaf.NotAfter) {
+			return fmt.Errorf("certificate expired at %s", leaf.NotAfter)
+		}
+		return nil
+	}
+}
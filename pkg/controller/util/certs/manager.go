@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certs
+
+import (
+	"context"
+	"github.com/atomix/runtime/pkg/logging"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/client-go/kubernetes"
+	"time"
+)
+
+var log = logging.GetLogger()
+
+// Options configures a certificate Manager
+type Options struct {
+	// Service is the name of the webhook service
+	Service string
+	// Namespace is the namespace of the webhook service
+	Namespace string
+	// Secret is the name of the Secret in the service namespace in which certificates are shared by all replicas
+	Secret string
+	// WebhookConfiguration is the name of the MutatingWebhookConfiguration to patch
+	WebhookConfiguration string
+	// ConversionWebhookPath is the path at which the service serves the conversion webhook
+	ConversionWebhookPath string
+	// CustomResourceDefinitions is the names of the CustomResourceDefinitions converted by the conversion webhook
+	CustomResourceDefinitions []string
+	// CertDir is the directory from which the webhook server loads its serving certificate
+	CertDir string
+	// Validity is the duration for which generated certificates are valid
+	Validity time.Duration
+	// RenewBefore is how long before expiration certificates are renewed
+	RenewBefore time.Duration
+}
+
+// NewManager creates a new certificate Manager
+func NewManager(client kubernetes.Interface, crdClient apiextensions.Interface, options Options) *Manager {
+	return &Manager{
+		client:    client,
+		crdClient: crdClient,
+		options:   options,
+	}
+}
+
+// Manager renews the webhook serving certificate before it expires.
+// Renewed certificates are stored in the shared certificates Secret and written to the webhook server's
+// certificate directory, from which the server hot-reloads them, and the CA is added to the webhook
+// configuration's and conversion webhooks' CA bundles.
+type Manager struct {
+	client    kubernetes.Interface
+	crdClient apiextensions.Interface
+	options   Options
+}
+
+// Start starts the certificate manager, renewing certificates until the context is cancelled
+func (m *Manager) Start(ctx context.Context) error {
+	for {
+		renewAt, err := m.reconcile(ctx)
+		if err != nil {
+			log.Error(err)
+			renewAt = time.Now().Add(time.Minute)
+		}
+
+		log.Infof("Next webhook certificate renewal at %s", renewAt)
+		timer := time.NewTimer(time.Until(renewAt))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		}
+	}
+}
+
+// NeedLeaderElection indicates certificates must be managed on all replicas, since the
+// webhook server runs on every replica regardless of leadership
+func (m *Manager) NeedLeaderElection() bool {
+	return false
+}
+
+// reconcile renews the serving certificate if necessary and returns the time at which it must next be renewed
+func (m *Manager) reconcile(ctx context.Context) (time.Time, error) {
+	cert, err := ReadKeyPair(m.options.CertDir)
+	if err == nil {
+		renewAt := cert.Cert.NotAfter.Add(-m.options.RenewBefore)
+		if time.Now().Before(renewAt) {
+			return renewAt, nil
+		}
+		log.Infof("Webhook certificate expires at %s; renewing", cert.Cert.NotAfter)
+	} else {
+		log.Warnf("Failed to load webhook certificate; loading certificate from Secret: %s", err)
+	}
+
+	cert, err = m.renew(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return cert.Cert.NotAfter.Add(-m.options.RenewBefore), nil
+}
+
+// renew loads the serving certificate from the certificates Secret, renewing it if necessary
+func (m *Manager) renew(ctx context.Context) (*KeyPair, error) {
+	bundle, err := RenewSecret(ctx, m.client, m.options.Namespace, m.options.Secret, m.options.Service, m.options.Validity, m.options.RenewBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	// Trust the CA before serving certificates issued by it
+	if err := PatchCABundle(ctx, m.client, m.options.WebhookConfiguration, bundle.CA.CertPEM); err != nil {
+		return nil, err
+	}
+	if err := PatchConversionWebhooks(ctx, m.crdClient, m.options.CustomResourceDefinitions,
+		m.options.Namespace, m.options.Service, m.options.ConversionWebhookPath, bundle.CA.CertPEM); err != nil {
+		return nil, err
+	}
+
+	if err := WriteKeyPair(m.options.CertDir, bundle.Serving); err != nil {
+		return nil, err
+	}
+	log.Infof("Loaded webhook certificate; expires at %s", bundle.Serving.Cert.NotAfter)
+	return bundle.Serving, nil
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certs
+
+import (
+	"context"
+	"fmt"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+	"time"
+)
+
+const (
+	// CAFile is the name of the CA certificate key in the certificates Secret
+	CAFile = "ca.crt"
+	// CAKeyFile is the name of the CA private key key in the certificates Secret
+	CAKeyFile = "ca.key"
+)
+
+// GetSecretName returns the default name of the Secret in which certificates for the given service are stored
+func GetSecretName(service string) string {
+	return fmt.Sprintf("%s-certs", service)
+}
+
+// GetOrCreateSecret loads the certificate bundle from the named Secret, creating the Secret if it does not exist.
+// When multiple replicas race to create the Secret, only one succeeds and the others load its bundle, so all
+// replicas serve certificates issued by the same CA.
+func GetOrCreateSecret(ctx context.Context, client kubernetes.Interface, namespace, name, service string, validity time.Duration) (*Bundle, error) {
+	secret, err := client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err == nil {
+		return parseSecret(secret)
+	} else if !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	bundle, err := NewBundle(service, namespace, validity)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Creating certificates Secret '%s/%s'", namespace, name)
+	_, err = client.CoreV1().Secrets(namespace).Create(ctx, newSecret(namespace, name, bundle), metav1.CreateOptions{})
+	if err == nil {
+		return bundle, nil
+	} else if !k8serrors.IsAlreadyExists(err) {
+		return nil, err
+	}
+
+	// Another replica created the Secret first
+	log.Infof("Certificates Secret '%s/%s' already exists; loading certificates", namespace, name)
+	secret, err = client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return parseSecret(secret)
+}
+
+// RenewSecret renews the serving certificate stored in the named Secret if it expires within renewBefore.
+// If another replica has already renewed the certificate, the renewed bundle is returned unchanged.
+func RenewSecret(ctx context.Context, client kubernetes.Interface, namespace, name, service string, validity, renewBefore time.Duration) (*Bundle, error) {
+	var bundle *Bundle
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				bundle, err = GetOrCreateSecret(ctx, client, namespace, name, service, validity)
+			}
+			return err
+		}
+
+		current, err := parseSecret(secret)
+		if err != nil {
+			log.Warnf("Failed to parse certificates Secret '%s/%s'; regenerating certificates: %s", namespace, name, err)
+		} else if time.Now().Add(renewBefore).Before(current.Serving.Cert.NotAfter) {
+			bundle = current
+			return nil
+		}
+
+		renewed, err := RenewBundle(current, service, namespace, validity)
+		if err != nil {
+			return err
+		}
+
+		log.Infof("Updating certificates Secret '%s/%s'", namespace, name)
+		secret.Type = corev1.SecretTypeTLS
+		secret.Data = getSecretData(renewed)
+		if _, err := client.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+		bundle = renewed
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+func newSecret(namespace, name string, bundle *Bundle) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: getSecretData(bundle),
+	}
+}
+
+func getSecretData(bundle *Bundle) map[string][]byte {
+	return map[string][]byte{
+		CertFile:  bundle.Serving.CertPEM,
+		KeyFile:   bundle.Serving.KeyPEM,
+		CAFile:    bundle.CA.CertPEM,
+		CAKeyFile: bundle.CA.KeyPEM,
+	}
+}
+
+func parseSecret(secret *corev1.Secret) (*Bundle, error) {
+	ca, err := ParseKeyPair(secret.Data[CAFile], secret.Data[CAKeyFile])
+	if err != nil {
+		return nil, err
+	}
+	serving, err := ParseKeyPair(secret.Data[CertFile], secret.Data[KeyFile])
+	if err != nil {
+		return nil, err
+	}
+	return &Bundle{
+		CA:      ca,
+		Serving: serving,
+	}, nil
+}
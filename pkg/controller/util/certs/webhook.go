@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certs
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+	"time"
+)
+
+// PatchCABundle adds the given CA to the bundle of each webhook in the named MutatingWebhookConfiguration.
+// CAs already in the bundle are retained until they expire so webhook clients continue to trust
+// certificates issued by other controller replicas or by a CA that is being rotated out.
+func PatchCABundle(ctx context.Context, client kubernetes.Interface, name string, caPEM []byte) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		webhook, err := client.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		changed := false
+		for i, wh := range webhook.Webhooks {
+			caBundle := mergeCABundle(wh.ClientConfig.CABundle, caPEM)
+			if !bytes.Equal(caBundle, wh.ClientConfig.CABundle) {
+				wh.ClientConfig.CABundle = caBundle
+				webhook.Webhooks[i] = wh
+				changed = true
+			}
+		}
+
+		if !changed {
+			return nil
+		}
+		_, err = client.AdmissionregistrationV1().MutatingWebhookConfigurations().Update(ctx, webhook, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// PatchInjectedCABundles adds the given CA to the bundle of each webhook in the Mutating- and
+// ValidatingWebhookConfigurations annotated to inject the CA from the given '<namespace>/<name>' Secret.
+func PatchInjectedCABundles(ctx context.Context, client kubernetes.Interface, secret string, caPEM []byte) error {
+	mutatingWebhooks, err := client.AdmissionregistrationV1().MutatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, webhook := range mutatingWebhooks.Items {
+		if webhook.Annotations[InjectCAFromAnnotation] == secret {
+			if err := PatchCABundle(ctx, client, webhook.Name, caPEM); err != nil {
+				return err
+			}
+		}
+	}
+
+	validatingWebhooks, err := client.AdmissionregistrationV1().ValidatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, webhook := range validatingWebhooks.Items {
+		if webhook.Annotations[InjectCAFromAnnotation] == secret {
+			if err := patchValidatingCABundle(ctx, client, webhook.Name, caPEM); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func patchValidatingCABundle(ctx context.Context, client kubernetes.Interface, name string, caPEM []byte) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		webhook, err := client.AdmissionregistrationV1().ValidatingWebhookConfigurations().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		changed := false
+		for i, wh := range webhook.Webhooks {
+			caBundle := mergeCABundle(wh.ClientConfig.CABundle, caPEM)
+			if !bytes.Equal(caBundle, wh.ClientConfig.CABundle) {
+				wh.ClientConfig.CABundle = caBundle
+				webhook.Webhooks[i] = wh
+				changed = true
+			}
+		}
+
+		if !changed {
+			return nil
+		}
+		_, err = client.AdmissionregistrationV1().ValidatingWebhookConfigurations().Update(ctx, webhook, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// mergeCABundle adds the given CA to the bundle, dropping expired and duplicate certificates
+func mergeCABundle(bundle []byte, caPEM []byte) []byte {
+	now := time.Now()
+	merged := new(bytes.Buffer)
+	seen := make(map[string]bool)
+	for _, data := range [][]byte{bundle, caPEM} {
+		for {
+			var block *pem.Block
+			block, data = pem.Decode(data)
+			if block == nil {
+				break
+			}
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil || now.After(cert.NotAfter) || seen[string(block.Bytes)] {
+				continue
+			}
+			seen[string(block.Bytes)] = true
+			_ = pem.Encode(merged, block)
+		}
+	}
+	return merged.Bytes()
+}
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certs
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	cryptorand "crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func newTestCertPEM(t *testing.T, serial int64, notAfter time.Time) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), cryptorand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject: pkix.Name{
+			Organization: []string{organization},
+		},
+		NotBefore:             notAfter.Add(-24 * time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(cryptorand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestMergeCABundle(t *testing.T) {
+	now := time.Now()
+	valid1 := newTestCertPEM(t, 1, now.Add(time.Hour))
+	valid2 := newTestCertPEM(t, 2, now.Add(time.Hour))
+	expired := newTestCertPEM(t, 3, now.Add(-time.Hour))
+	join := func(certs ...[]byte) []byte {
+		return bytes.Join(certs, nil)
+	}
+
+	tests := []struct {
+		name   string
+		bundle []byte
+		caPEM  []byte
+		want   []byte
+	}{
+		{
+			name:  "empty bundle",
+			caPEM: valid1,
+			want:  valid1,
+		},
+		{
+			name:   "retain valid CAs",
+			bundle: valid1,
+			caPEM:  valid2,
+			want:   join(valid1, valid2),
+		},
+		{
+			name:   "drop expired CAs",
+			bundle: join(expired, valid1),
+			caPEM:  valid2,
+			want:   join(valid1, valid2),
+		},
+		{
+			name:   "drop duplicate CAs",
+			bundle: join(valid1, valid2),
+			caPEM:  valid1,
+			want:   join(valid1, valid2),
+		},
+		{
+			name:   "drop invalid certificates",
+			bundle: join(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("invalid")}), valid1),
+			caPEM:  valid2,
+			want:   join(valid1, valid2),
+		},
+		{
+			name:   "expired CA",
+			bundle: valid1,
+			caPEM:  expired,
+			want:   valid1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := mergeCABundle(test.bundle, test.caPEM)
+			if !bytes.Equal(got, test.want) {
+				t.Errorf("mergeCABundle() = %s, want %s", got, test.want)
+			}
+			if again := mergeCABundle(got, test.caPEM); !bytes.Equal(again, got) {
+				t.Errorf("mergeCABundle() is not idempotent: %s, want %s", again, got)
+			}
+		})
+	}
+}
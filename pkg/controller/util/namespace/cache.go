@@ -0,0 +1,463 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package namespace
+
+import (
+	"context"
+	"fmt"
+	"github.com/atomix/runtime/pkg/logging"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sync"
+	"time"
+)
+
+var log = logging.GetLogger()
+
+// NewCacheBuilder returns a function creating a Cache initially watching the given namespaces
+func NewCacheBuilder(namespaces ...string) cache.NewCacheFunc {
+	return func(config *rest.Config, options cache.Options) (cache.Cache, error) {
+		options.Namespace = corev1.NamespaceAll
+		clusterCache, err := cache.New(config, options)
+		if err != nil {
+			return nil, err
+		}
+
+		c := &Cache{
+			config:       config,
+			options:      options,
+			scheme:       options.Scheme,
+			mapper:       options.Mapper,
+			clusterCache: clusterCache,
+			namespaces:   make(map[string]*namespaceCache),
+			informers:    make(map[schema.GroupVersionKind]*namespacesInformer),
+		}
+		for _, namespace := range namespaces {
+			if err := c.AddNamespace(namespace); err != nil {
+				return nil, err
+			}
+		}
+		return c, nil
+	}
+}
+
+// Cache is a cache.Cache for a dynamic set of namespaces
+// Namespaced objects are cached per namespace, and namespaces can be added to or removed from the
+// cache at any time. Event handlers and indexes registered with the cache apply to all namespaces,
+// including those added later. Cluster-scoped objects are cached in a single cluster-wide cache.
+type Cache struct {
+	config       *rest.Config
+	options      cache.Options
+	scheme       *runtime.Scheme
+	mapper       apimeta.RESTMapper
+	clusterCache cache.Cache
+	namespaces   map[string]*namespaceCache
+	informers    map[schema.GroupVersionKind]*namespacesInformer
+	indexes      []index
+	ctx          context.Context
+	mu           sync.RWMutex
+}
+
+type namespaceCache struct {
+	cache.Cache
+	cancel context.CancelFunc
+}
+
+type namespaceInformer struct {
+	cache    *namespaceCache
+	informer cache.Informer
+}
+
+type index struct {
+	object  client.Object
+	field   string
+	extract client.IndexerFunc
+}
+
+// AddNamespace adds a namespace to the cache
+func (c *Cache) AddNamespace(namespace string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.namespaces[namespace]; ok {
+		return nil
+	}
+
+	log.Infof("Watching namespace '%s'", namespace)
+	options := c.options
+	options.Namespace = namespace
+	nsCache, err := cache.New(c.config, options)
+	if err != nil {
+		return err
+	}
+
+	// Register all indexes and informers with the namespace's cache before starting it
+	ctx := context.Background()
+	for _, index := range c.indexes {
+		if err := nsCache.IndexField(ctx, index.object, index.field, index.extract); err != nil {
+			return err
+		}
+	}
+	for _, informer := range c.informers {
+		nsInformer, err := nsCache.GetInformer(ctx, informer.object)
+		if err != nil {
+			return err
+		}
+		if err := informer.addNamespace(namespace, nsInformer); err != nil {
+			return err
+		}
+	}
+
+	cancel := func() {}
+	if c.ctx != nil {
+		ctx, cancel = context.WithCancel(c.ctx)
+		go startCache(ctx, namespace, nsCache)
+	}
+	c.namespaces[namespace] = &namespaceCache{
+		Cache:  nsCache,
+		cancel: cancel,
+	}
+	return nil
+}
+
+// RemoveNamespace removes a namespace from the cache
+func (c *Cache) RemoveNamespace(namespace string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	nsCache, ok := c.namespaces[namespace]
+	if !ok {
+		return
+	}
+
+	log.Infof("Stopped watching namespace '%s'", namespace)
+	nsCache.cancel()
+	delete(c.namespaces, namespace)
+	for _, informer := range c.informers {
+		informer.removeNamespace(namespace)
+	}
+}
+
+// GetInformer gets an informer for the given object across all namespaces
+func (c *Cache) GetInformer(ctx context.Context, obj client.Object) (cache.Informer, error) {
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return nil, err
+	}
+	return c.getInformer(ctx, gvk, obj)
+}
+
+// GetInformerForKind gets an informer for the given kind across all namespaces
+func (c *Cache) GetInformerForKind(ctx context.Context, gvk schema.GroupVersionKind) (cache.Informer, error) {
+	obj, err := c.scheme.New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	object, ok := obj.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a client.Object", gvk)
+	}
+	return c.getInformer(ctx, gvk, object)
+}
+
+func (c *Cache) getInformer(ctx context.Context, gvk schema.GroupVersionKind, obj client.Object) (cache.Informer, error) {
+	if ok, err := c.isNamespaced(gvk); err != nil {
+		return nil, err
+	} else if !ok {
+		return c.clusterCache.GetInformerForKind(ctx, gvk)
+	}
+
+	// Informers for started namespace caches block until they've synced, so they're created outside
+	// the lock and swapped in once an informer has been created for each namespace in the cache.
+	nsInformers := make(map[string]namespaceInformer)
+	for {
+		c.mu.Lock()
+		if informer, ok := c.informers[gvk]; ok {
+			c.mu.Unlock()
+			return informer, nil
+		}
+
+		missing := make(map[string]*namespaceCache)
+		for namespace, nsCache := range c.namespaces {
+			if nsInformer, ok := nsInformers[namespace]; !ok || nsInformer.cache != nsCache {
+				missing[namespace] = nsCache
+			}
+		}
+
+		if len(missing) == 0 {
+			informer := &namespacesInformer{
+				object:    obj,
+				informers: make(map[string]cache.Informer),
+			}
+			for namespace := range c.namespaces {
+				if err := informer.addNamespace(namespace, nsInformers[namespace].informer); err != nil {
+					c.mu.Unlock()
+					return nil, err
+				}
+			}
+			c.informers[gvk] = informer
+			c.mu.Unlock()
+			return informer, nil
+		}
+		c.mu.Unlock()
+
+		for namespace, nsCache := range missing {
+			nsInformer, err := nsCache.GetInformer(ctx, obj)
+			if err != nil {
+				return nil, err
+			}
+			nsInformers[namespace] = namespaceInformer{
+				cache:    nsCache,
+				informer: nsInformer,
+			}
+		}
+	}
+}
+
+// Start starts the cache, blocking until the context is cancelled
+func (c *Cache) Start(ctx context.Context) error {
+	c.mu.Lock()
+	c.ctx = ctx
+	go startCache(ctx, "", c.clusterCache)
+	for namespace, nsCache := range c.namespaces {
+		nsCtx, cancel := context.WithCancel(ctx)
+		nsCache.cancel = cancel
+		go startCache(nsCtx, namespace, nsCache.Cache)
+	}
+	c.mu.Unlock()
+	<-ctx.Done()
+	return nil
+}
+
+func startCache(ctx context.Context, namespace string, c cache.Cache) {
+	if err := c.Start(ctx); err != nil {
+		log.Errorf("Failed to start cache for namespace '%s': %s", namespace, err)
+	}
+}
+
+// WaitForCacheSync waits for the caches for all namespaces to sync
+func (c *Cache) WaitForCacheSync(ctx context.Context) bool {
+	if !c.clusterCache.WaitForCacheSync(ctx) {
+		return false
+	}
+	for _, nsCache := range c.getNamespaceCaches() {
+		if !nsCache.WaitForCacheSync(ctx) {
+			return false
+		}
+	}
+	return true
+}
+
+// IndexField adds an index to the caches for all namespaces
+func (c *Cache) IndexField(ctx context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error {
+	if ok, err := c.isObjectNamespaced(obj); err != nil {
+		return err
+	} else if !ok {
+		return c.clusterCache.IndexField(ctx, obj, field, extractValue)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, nsCache := range c.namespaces {
+		if err := nsCache.IndexField(ctx, obj, field, extractValue); err != nil {
+			return err
+		}
+	}
+	c.indexes = append(c.indexes, index{
+		object:  obj,
+		field:   field,
+		extract: extractValue,
+	})
+	return nil
+}
+
+// Get gets an object from the cache
+// Objects in namespaces that are not watched are not found.
+func (c *Cache) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return err
+	}
+	if ok, err := c.isNamespaced(gvk); err != nil {
+		return err
+	} else if !ok {
+		return c.clusterCache.Get(ctx, key, obj)
+	}
+
+	nsCache, ok := c.getNamespaceCache(key.Namespace)
+	if !ok {
+		mapping, err := c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return err
+		}
+		return k8serrors.NewNotFound(mapping.Resource.GroupResource(), key.Name)
+	}
+	return nsCache.Get(ctx, key, obj)
+}
+
+// List lists objects in the cache
+// Objects in namespaces that are not watched are not listed.
+func (c *Cache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if ok, err := c.isObjectNamespaced(list); err != nil {
+		return err
+	} else if !ok {
+		return c.clusterCache.List(ctx, list, opts...)
+	}
+
+	listOpts := client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	if listOpts.Namespace != corev1.NamespaceAll {
+		nsCache, ok := c.getNamespaceCache(listOpts.Namespace)
+		if !ok {
+			return apimeta.SetList(list, nil)
+		}
+		return nsCache.List(ctx, list, opts...)
+	}
+
+	var items []runtime.Object
+	for _, nsCache := range c.getNamespaceCaches() {
+		nsList := list.DeepCopyObject().(client.ObjectList)
+		if err := nsCache.List(ctx, nsList, &listOpts); err != nil {
+			return err
+		}
+		nsItems, err := apimeta.ExtractList(nsList)
+		if err != nil {
+			return err
+		}
+		items = append(items, nsItems...)
+	}
+	return apimeta.SetList(list, items)
+}
+
+func (c *Cache) getNamespaceCache(namespace string) (cache.Cache, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	nsCache, ok := c.namespaces[namespace]
+	if !ok {
+		return nil, false
+	}
+	return nsCache.Cache, true
+}
+
+func (c *Cache) getNamespaceCaches() []cache.Cache {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	caches := make([]cache.Cache, 0, len(c.namespaces))
+	for _, nsCache := range c.namespaces {
+		caches = append(caches, nsCache.Cache)
+	}
+	return caches
+}
+
+func (c *Cache) isObjectNamespaced(obj runtime.Object) (bool, error) {
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return false, err
+	}
+	return c.isNamespaced(gvk)
+}
+
+func (c *Cache) isNamespaced(gvk schema.GroupVersionKind) (bool, error) {
+	mapping, err := c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return false, err
+	}
+	return mapping.Scope.Name() != apimeta.RESTScopeNameRoot, nil
+}
+
+var _ cache.Cache = (*Cache)(nil)
+
+// namespacesInformer is an informer for a kind across a dynamic set of namespaces
+// Event handlers and indexers are registered with the informers for namespaces added later.
+type namespacesInformer struct {
+	object    client.Object
+	informers map[string]cache.Informer
+	handlers  []eventHandler
+	indexers  []toolscache.Indexers
+	mu        sync.RWMutex
+}
+
+type eventHandler struct {
+	handler      toolscache.ResourceEventHandler
+	resyncPeriod *time.Duration
+}
+
+func (i *namespacesInformer) addNamespace(namespace string, informer cache.Informer) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, indexers := range i.indexers {
+		if err := informer.AddIndexers(indexers); err != nil {
+			return err
+		}
+	}
+	for _, handler := range i.handlers {
+		if handler.resyncPeriod != nil {
+			informer.AddEventHandlerWithResyncPeriod(handler.handler, *handler.resyncPeriod)
+		} else {
+			informer.AddEventHandler(handler.handler)
+		}
+	}
+	i.informers[namespace] = informer
+	return nil
+}
+
+func (i *namespacesInformer) removeNamespace(namespace string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.informers, namespace)
+}
+
+// AddEventHandler adds the handler to the informers for all namespaces
+func (i *namespacesInformer) AddEventHandler(handler toolscache.ResourceEventHandler) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.handlers = append(i.handlers, eventHandler{handler: handler})
+	for _, informer := range i.informers {
+		informer.AddEventHandler(handler)
+	}
+}
+
+// AddEventHandlerWithResyncPeriod adds the handler with a resync period to the informers for all namespaces
+func (i *namespacesInformer) AddEventHandlerWithResyncPeriod(handler toolscache.ResourceEventHandler, resyncPeriod time.Duration) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.handlers = append(i.handlers, eventHandler{handler: handler, resyncPeriod: &resyncPeriod})
+	for _, informer := range i.informers {
+		informer.AddEventHandlerWithResyncPeriod(handler, resyncPeriod)
+	}
+}
+
+// AddIndexers adds the indexers to the informers for all namespaces
+func (i *namespacesInformer) AddIndexers(indexers toolscache.Indexers) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.indexers = append(i.indexers, indexers)
+	for _, informer := range i.informers {
+		if err := informer.AddIndexers(indexers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HasSynced returns whether the informers for all namespaces have synced
+func (i *namespacesInformer) HasSynced() bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	for _, informer := range i.informers {
+		if !informer.HasSynced() {
+			return false
+		}
+	}
+	return true
+}
+
+var _ cache.Informer = (*namespacesInformer)(nil)
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package namespace
+
+import (
+	"context"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	toolscache "k8s.io/client-go/tools/cache"
+	"time"
+)
+
+const selectorResyncPeriod = 10 * time.Minute
+
+// NewSelectorWatcher returns a watcher that adds namespaces matching the selector to the cache
+// and removes them when they no longer match
+func NewSelectorWatcher(client kubernetes.Interface, selector labels.Selector, cache *Cache) *SelectorWatcher {
+	return &SelectorWatcher{
+		client:   client,
+		selector: selector,
+		cache:    cache,
+	}
+}
+
+// SelectorWatcher keeps the namespaces in a Cache in sync with a namespace label selector
+type SelectorWatcher struct {
+	client   kubernetes.Interface
+	selector labels.Selector
+	cache    *Cache
+}
+
+// Start watches namespaces matching the selector until the context is cancelled
+func (w *SelectorWatcher) Start(ctx context.Context) error {
+	log.Infof("Watching namespaces matching '%s'", w.selector)
+	factory := informers.NewSharedInformerFactoryWithOptions(w.client, selectorResyncPeriod,
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = w.selector.String()
+		}))
+	informer := factory.Core().V1().Namespaces().Informer()
+	informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			w.update(obj)
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			w.update(obj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if namespace, ok := obj.(*corev1.Namespace); ok {
+				w.cache.RemoveNamespace(namespace.Name)
+			}
+		},
+	})
+	factory.Start(ctx.Done())
+	<-ctx.Done()
+	return nil
+}
+
+func (w *SelectorWatcher) update(obj interface{}) {
+	namespace, ok := obj.(*corev1.Namespace)
+	if !ok {
+		return
+	}
+	if !w.selector.Matches(labels.Set(namespace.Labels)) || namespace.Status.Phase == corev1.NamespaceTerminating {
+		w.cache.RemoveNamespace(namespace.Name)
+		return
+	}
+	if err := w.cache.AddNamespace(namespace.Name); err != nil {
+		log.Error(err)
+	}
+}
+
+// NeedLeaderElection indicates the watcher runs on all replicas
+func (w *SelectorWatcher) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,204 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package portforward
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Dialer dials pod ports through the Kubernetes API server's port-forward subresource
+type Dialer struct {
+	client   rest.Interface
+	upgrader spdy.Upgrader
+	http     *http.Client
+}
+
+// NewDialer returns a new port-forward Dialer for the given API server configuration
+func NewDialer(config *rest.Config) (*Dialer, error) {
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	roundTripper, upgrader, err := spdy.RoundTripperFor(config)
+	if err != nil {
+		return nil, err
+	}
+	return &Dialer{
+		client:   client.CoreV1().RESTClient(),
+		upgrader: upgrader,
+		http: &http.Client{
+			Transport: roundTripper,
+		},
+	}, nil
+}
+
+// DialPod opens a connection to the given port of the named pod
+// Each connection is tunneled through its own port-forward session, which is closed with the connection.
+func (d *Dialer) DialPod(ctx context.Context, namespace string, name string, port int) (net.Conn, error) {
+	url := d.client.Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(name).
+		SubResource("portforward").
+		URL()
+	dialer := spdy.NewDialer(d.upgrader, d.http, http.MethodPost, url)
+
+	type dialResult struct {
+		conn httpstream.Connection
+		err  error
+	}
+	ch := make(chan dialResult, 1)
+	go func() {
+		conn, _, err := dialer.Dial(portforward.PortForwardProtocolV1Name)
+		ch <- dialResult{conn, err}
+	}()
+
+	var streamConn httpstream.Connection
+	select {
+	case result := <-ch:
+		if result.err != nil {
+			return nil, result.err
+		}
+		streamConn = result.conn
+	case <-ctx.Done():
+		go func() {
+			if result := <-ch; result.conn != nil {
+				result.conn.Close()
+			}
+		}()
+		return nil, ctx.Err()
+	}
+
+	headers := http.Header{}
+	headers.Set(corev1.StreamType, corev1.StreamTypeError)
+	headers.Set(corev1.PortHeader, strconv.Itoa(port))
+	headers.Set(corev1.PortForwardRequestIDHeader, "0")
+	errorStream, err := streamConn.CreateStream(headers)
+	if err != nil {
+		streamConn.Close()
+		return nil, err
+	}
+	// The error stream is only read from
+	errorStream.Close()
+
+	headers.Set(corev1.StreamType, corev1.StreamTypeData)
+	dataStream, err := streamConn.CreateStream(headers)
+	if err != nil {
+		streamConn.Close()
+		return nil, err
+	}
+
+	conn := &podConn{
+		conn:   streamConn,
+		stream: dataStream,
+		local:  podAddr(fmt.Sprintf("%s/%s", namespace, name)),
+		remote: podAddr(fmt.Sprintf("%s/%s:%d", namespace, name, port)),
+	}
+
+	// Close the connection if the API server reports an error forwarding the port
+	go func() {
+		message, err := io.ReadAll(errorStream)
+		if err == nil && len(message) > 0 {
+			conn.closeWithError(errors.New(string(message)))
+		}
+	}()
+	return conn, nil
+}
+
+// podAddr is the address of a port-forwarded pod
+type podAddr string
+
+func (a podAddr) Network() string {
+	return "portforward"
+}
+
+func (a podAddr) String() string {
+	return string(a)
+}
+
+// podConn is a net.Conn over a port-forward data stream
+// Deadlines are not supported by port-forward streams and are ignored.
+type podConn struct {
+	conn   httpstream.Connection
+	stream httpstream.Stream
+	local  net.Addr
+	remote net.Addr
+	err    error
+	mu     sync.Mutex
+}
+
+func (c *podConn) Read(b []byte) (int, error) {
+	n, err := c.stream.Read(b)
+	if err != nil {
+		if closeErr := c.getError(); closeErr != nil {
+			return n, closeErr
+		}
+	}
+	return n, err
+}
+
+func (c *podConn) Write(b []byte) (int, error) {
+	n, err := c.stream.Write(b)
+	if err != nil {
+		if closeErr := c.getError(); closeErr != nil {
+			return n, closeErr
+		}
+	}
+	return n, err
+}
+
+func (c *podConn) Close() error {
+	c.stream.Close()
+	return c.conn.Close()
+}
+
+func (c *podConn) closeWithError(err error) {
+	c.mu.Lock()
+	c.err = err
+	c.mu.Unlock()
+	c.Close()
+}
+
+func (c *podConn) getError() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+func (c *podConn) LocalAddr() net.Addr {
+	return c.local
+}
+
+func (c *podConn) RemoteAddr() net.Addr {
+	return c.remote
+}
+
+func (c *podConn) SetDeadline(t time.Time) error {
+	return nil
+}
+
+func (c *podConn) SetReadDeadline(t time.Time) error {
+	return nil
+}
+
+func (c *podConn) SetWriteDeadline(t time.Time) error {
+	return nil
+}
+
+var _ net.Conn = &podConn{}
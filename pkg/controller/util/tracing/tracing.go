@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Options configures trace export
+type Options struct {
+	// ServiceName is the name of the service reported in exported spans
+	ServiceName string
+	// Endpoint is the address of the OTLP gRPC collector to which spans are exported
+	// If the endpoint is empty, tracing is disabled.
+	Endpoint string
+	// Insecure disables TLS for connections to the collector
+	Insecure bool
+	// SampleRatio is the ratio of root traces to sample
+	SampleRatio float64
+}
+
+// Setup configures the global tracer provider to export spans to an OTLP collector.
+// The returned function flushes and stops the exporter. If no endpoint is configured, tracing
+// is disabled: spans are not recorded, but trace context is still propagated.
+func Setup(ctx context.Context, options Options) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if options.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	clientOptions := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(options.Endpoint),
+	}
+	if options.Insecure {
+		clientOptions = append(clientOptions, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, clientOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(options.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(options.ServiceName))))
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// RecordError records the given error on the span, if any
+func RecordError(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}